@@ -2,6 +2,7 @@ package hivego
 
 import (
 	"encoding/json"
+	"fmt"
 	"time"
 )
 
@@ -9,12 +10,6 @@ type CustomTime time.Time
 
 const customTimeLayout = "2006-01-02T15:04:05"
 
-type Authority struct {
-	AccountAuths    [][]interface{} `json:"account_auths"`
-	KeyAuths        [][]interface{} `json:"key_auths"`
-	WeightThreshold int             `json:"weight_threshold"`
-}
-
 type RC struct {
 	CurrentMana    int64 `json:"current_mana"`
 	LastUpdateTime int64 `json:"last_update_time"`
@@ -41,25 +36,25 @@ type AccountData struct {
 	CanVote                       bool          `json:"can_vote"`
 	VotingPower                   int16         `json:"voting_power"`
 	LastVoteTime                  CustomTime    `json:"last_vote_time"`
-	Balance                       string        `json:"balance"`
-	SavingsBalance                string        `json:"savings_balance"`
-	HbdBalance                    string        `json:"hbd_balance"`
+	Balance                       Asset         `json:"balance"`
+	SavingsBalance                Asset         `json:"savings_balance"`
+	HbdBalance                    Asset         `json:"hbd_balance"`
 	HbdSeconds                    string        `json:"hbd_seconds"`
 	HbdSecondsLastUpdate          CustomTime    `json:"hbd_seconds_last_update"`
 	HbdLastInterestPayment        CustomTime    `json:"hbd_last_interest_payment"`
-	SavingsHbdBalance             string        `json:"savings_hbd_balance"`
+	SavingsHbdBalance             Asset         `json:"savings_hbd_balance"`
 	SavingsHbdSeconds             string        `json:"savings_hbd_seconds"`
 	SavingsHbdLastUpdate          CustomTime    `json:"savings_hbd_last_update"`
 	SavingsHbdLastInterestPayment CustomTime    `json:"savings_hbd_last_interest_payment"`
 	SavingsWithdrawRequests       int32         `json:"savings_withdraw_requests"`
-	RewardHbdBalance              string        `json:"reward_hbd_balance"`
-	RewardHiveBalance             string        `json:"reward_hive_balance"`
-	RewardVestingBalance          string        `json:"reward_vesting_balance"`
-	RewardVestingHive             string        `json:"reward_vesting_hive"`
-	VestingShares                 string        `json:"vesting_shares"`
-	DelegatedVestingShares        string        `json:"delegated_vesting_shares"`
-	ReceivedVestingShares         string        `json:"received_vesting_shares"`
-	VestingWithdrawRate           string        `json:"vesting_withdraw_rate"`
+	RewardHbdBalance              Asset         `json:"reward_hbd_balance"`
+	RewardHiveBalance             Asset         `json:"reward_hive_balance"`
+	RewardVestingBalance          Asset         `json:"reward_vesting_balance"`
+	RewardVestingHive             Asset         `json:"reward_vesting_hive"`
+	VestingShares                 Asset         `json:"vesting_shares"`
+	DelegatedVestingShares        Asset         `json:"delegated_vesting_shares"`
+	ReceivedVestingShares         Asset         `json:"received_vesting_shares"`
+	VestingWithdrawRate           Asset         `json:"vesting_withdraw_rate"`
 	NextVestingWithdrawal         CustomTime    `json:"next_vesting_withdrawal"`
 	Withdrawn                     int64         `json:"withdrawn"`
 	ToWithdraw                    int64         `json:"to_withdraw"`
@@ -73,7 +68,7 @@ type AccountData struct {
 	AverageBandwidth              string        `json:"average_bandwidth"`
 	LifetimeBandwidth             string        `json:"lifetime_bandwidth"`
 	LastBandwidthUpdate           CustomTime    `json:"last_bandwidth_update"`
-	PostVotingPower               string        `json:"post_voting_power"`
+	PostVotingPower               Asset         `json:"post_voting_power"`
 	Reputation                    int64         `json:"reputation"`
 	PostBandwidth                 int64         `json:"post_bandwidth"`
 	PendingClaimedAccounts        int32         `json:"pending_claimed_accounts"`
@@ -105,6 +100,10 @@ func (ct *CustomTime) UnmarshalJSON(b []byte) error {
 	return nil
 }
 
+func (ct CustomTime) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + time.Time(ct).UTC().Format(customTimeLayout) + `"`), nil
+}
+
 func (ct CustomTime) ToTime() time.Time {
 	return time.Time(ct)
 }
@@ -127,3 +126,15 @@ func (h *HiveRpcNode) GetAccount(accountNames []string) ([]AccountData, error) {
 	}
 	return accountData, nil
 }
+
+// Fetches a single account, failing if it does not exist
+func (h *HiveRpcNode) getSingleAccount(accountName string) (AccountData, error) {
+	accounts, err := h.GetAccount([]string{accountName})
+	if err != nil {
+		return AccountData{}, err
+	}
+	if len(accounts) == 0 {
+		return AccountData{}, fmt.Errorf("account %s not found", accountName)
+	}
+	return accounts[0], nil
+}
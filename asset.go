@@ -0,0 +1,363 @@
+package hivego
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// An amount of HIVE, HBD or VESTS (TESTS and TBD on testnets), held as an
+// integer in the smallest unit of the asset. Amount 1234 with precision 3 is
+// "1.234 HIVE".
+type Asset struct {
+	Amount    int64
+	Precision uint8
+	Symbol    string
+}
+
+type assetInfo struct {
+	precision uint8
+	nai       string
+	// nai number as serialized by the chain, without the precision bits
+	naiNum uint32
+	// symbol in the legacy binary form, which kept the Steem names
+	legacySymbol string
+}
+
+var assets = map[string]assetInfo{
+	"HIVE":  {3, "@@000000021", 99999999 + 2, "STEEM"},
+	"HBD":   {3, "@@000000013", 99999999 + 1, "SBD"},
+	"VESTS": {6, "@@000000037", 99999999 + 3, "VESTS"},
+	"TESTS": {3, "@@000000021", 99999999 + 2, "TESTS"},
+	"TBD":   {3, "@@000000013", 99999999 + 1, "TBD"},
+}
+
+// Testnets share the mainnet nais but name the assets TESTS and TBD
+var testnetSymbols = map[string]string{"HIVE": "TESTS", "HBD": "TBD"}
+
+// Whether chainId is the one of a testnet, empty being mainnet
+func isTestnet(chainId string) bool {
+	return chainId != "" && chainId != hex.EncodeToString(getHiveChainId())
+}
+
+// Returns the symbols nais are read as, one per nai
+func naiSymbols(testnet bool) []string {
+	if testnet {
+		return []string{"TESTS", "TBD", "VESTS"}
+	}
+	return []string{"HIVE", "HBD", "VESTS"}
+}
+
+// Returns the asset with the symbol it has on the chain of chainId: TESTS
+// and TBD rather than HIVE and HBD on a testnet. Assets read from a nai in
+// JSON get the mainnet symbols, the chain id is not known there.
+func (a Asset) OnChain(chainId string) Asset {
+	if symbol, ok := testnetSymbols[a.Symbol]; ok && isTestnet(chainId) {
+		a.Symbol = symbol
+	}
+	return a
+}
+
+// Returns a copy of op with its assets converted with Asset.OnChain
+func operationOnChain(op HiveOperation, chainId string) HiveOperation {
+	if !isTestnet(chainId) {
+		return op
+	}
+	v := reflect.ValueOf(op)
+	converted := reflect.New(v.Type()).Elem()
+	converted.Set(v)
+	assetsOnChain(converted, chainId)
+	return converted.Interface().(HiveOperation)
+}
+
+// Converts the assets in the settable v, copying slices and pointers so the
+// caller's values are left untouched
+func assetsOnChain(v reflect.Value, chainId string) {
+	if v.Type() == assetType {
+		v.Set(reflect.ValueOf(v.Interface().(Asset).OnChain(chainId)))
+		return
+	}
+	switch v.Kind() {
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			if v.Type().Field(i).PkgPath == "" {
+				assetsOnChain(v.Field(i), chainId)
+			}
+		}
+	case reflect.Slice:
+		if v.IsNil() || v.Type().Elem().Kind() == reflect.Uint8 {
+			return
+		}
+		c := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		reflect.Copy(c, v)
+		for i := 0; i < c.Len(); i++ {
+			assetsOnChain(c.Index(i), chainId)
+		}
+		v.Set(c)
+	case reflect.Ptr:
+		if v.IsNil() {
+			return
+		}
+		c := reflect.New(v.Type().Elem())
+		c.Elem().Set(v.Elem())
+		assetsOnChain(c.Elem(), chainId)
+		v.Set(c)
+	}
+}
+
+// Returns an asset of amount in the smallest unit of symbol
+func NewAsset(amount int64, symbol string) (Asset, error) {
+	info, ok := assets[symbol]
+	if !ok {
+		return Asset{}, fmt.Errorf("unknown asset symbol %s", symbol)
+	}
+	return Asset{amount, info.precision, symbol}, nil
+}
+
+// Parses a legacy asset string such as "1.000 HIVE". Fewer decimals than the
+// precision of the asset are accepted, more are an error.
+func ParseAsset(s string) (Asset, error) {
+	parts := strings.Split(s, " ")
+	if len(parts) != 2 {
+		return Asset{}, errors.New("invalid asset format: " + s)
+	}
+	asset, err := NewAsset(0, parts[1])
+	if err != nil {
+		return Asset{}, err
+	}
+
+	amountStr := parts[0]
+	negative := strings.HasPrefix(amountStr, "-")
+	amountStr = strings.TrimPrefix(amountStr, "-")
+
+	whole, decimals := amountStr, ""
+	if i := strings.Index(amountStr, "."); i >= 0 {
+		whole, decimals = amountStr[:i], amountStr[i+1:]
+	}
+	if whole == "" || strings.Trim(whole+decimals, "0123456789") != "" {
+		return Asset{}, errors.New("invalid amount format: " + parts[0])
+	}
+	if len(decimals) > int(asset.Precision) {
+		return Asset{}, fmt.Errorf("%s has more than %d decimals", s, asset.Precision)
+	}
+	decimals += strings.Repeat("0", int(asset.Precision)-len(decimals))
+
+	amount, err := strconv.ParseInt(whole+decimals, 10, 64)
+	if err != nil {
+		return Asset{}, fmt.Errorf("invalid amount %s: %w", parts[0], err)
+	}
+	if negative {
+		amount = -amount
+	}
+	asset.Amount = amount
+	return asset, nil
+}
+
+// Parses a legacy asset string and panics on error, for constants
+func MustParseAsset(s string) Asset {
+	a, err := ParseAsset(s)
+	if err != nil {
+		panic(err)
+	}
+	return a
+}
+
+// Formats the asset as a legacy asset string such as "1.000 HIVE"
+func (a Asset) String() string {
+	return formatLegacyAsset(a.Amount, int(a.Precision), a.Symbol)
+}
+
+// Returns the NAI of the asset, e.g. "@@000000021" for HIVE
+func (a Asset) Nai() string {
+	return assets[a.Symbol].nai
+}
+
+func (a Asset) IsZero() bool {
+	return a.Amount == 0
+}
+
+func (a Asset) checkSymbol(symbols ...string) error {
+	for _, symbol := range symbols {
+		if a.Symbol == symbol {
+			return nil
+		}
+	}
+	return fmt.Errorf("expected %s amount, got %s", strings.Join(symbols, " or "), a)
+}
+
+// Parses a legacy asset string that must be in one of symbols
+func parseAssetOf(s string, symbols ...string) (Asset, error) {
+	a, err := ParseAsset(s)
+	if err != nil {
+		return Asset{}, err
+	}
+	return a, a.checkSymbol(symbols...)
+}
+
+func (a Asset) checkSameAsset(b Asset) error {
+	if a.Symbol != b.Symbol || a.Precision != b.Precision {
+		return fmt.Errorf("can't combine %s and %s", a, b)
+	}
+	return nil
+}
+
+func (a Asset) Add(b Asset) (Asset, error) {
+	if err := a.checkSameAsset(b); err != nil {
+		return Asset{}, err
+	}
+	if (b.Amount > 0 && a.Amount > math.MaxInt64-b.Amount) || (b.Amount < 0 && a.Amount < math.MinInt64-b.Amount) {
+		return Asset{}, fmt.Errorf("%s + %s overflows", a, b)
+	}
+	a.Amount += b.Amount
+	return a, nil
+}
+
+func (a Asset) Sub(b Asset) (Asset, error) {
+	if err := a.checkSameAsset(b); err != nil {
+		return Asset{}, err
+	}
+	if (b.Amount < 0 && a.Amount > math.MaxInt64+b.Amount) || (b.Amount > 0 && a.Amount < math.MinInt64+b.Amount) {
+		return Asset{}, fmt.Errorf("%s - %s overflows", a, b)
+	}
+	a.Amount -= b.Amount
+	return a, nil
+}
+
+// Returns -1, 0 or 1 if a is less than, equal to or greater than b
+func (a Asset) Cmp(b Asset) (int, error) {
+	if err := a.checkSameAsset(b); err != nil {
+		return 0, err
+	}
+	switch {
+	case a.Amount < b.Amount:
+		return -1, nil
+	case a.Amount > b.Amount:
+		return 1, nil
+	}
+	return 0, nil
+}
+
+// Marshals to the legacy string form used by condenser_api. An unset Asset
+// marshals to null.
+func (a Asset) MarshalJSON() ([]byte, error) {
+	if a == (Asset{}) {
+		return []byte("null"), nil
+	}
+	if _, ok := assets[a.Symbol]; !ok {
+		return nil, fmt.Errorf("unknown asset symbol %q", a.Symbol)
+	}
+	return json.Marshal(a.String())
+}
+
+// Accepts the legacy string, the HF26 object {"amount", "precision", "nai"}
+// and the [amount, precision, nai] array forms
+func (a *Asset) UnmarshalJSON(b []byte) error {
+	if string(b) == "null" {
+		return nil
+	}
+	var legacy string
+	if err := json.Unmarshal(b, &legacy); err == nil {
+		parsed, err := ParseAsset(legacy)
+		if err != nil {
+			return err
+		}
+		*a = parsed
+		return nil
+	}
+
+	var nai naiAsset
+	if err := json.Unmarshal(b, &nai); err != nil {
+		var tuple []json.RawMessage
+		if json.Unmarshal(b, &tuple) != nil || len(tuple) != 3 {
+			return fmt.Errorf("invalid asset %s", string(b))
+		}
+		if err := json.Unmarshal(tuple[0], &nai.Amount); err != nil {
+			return err
+		}
+		if err := json.Unmarshal(tuple[1], &nai.Precision); err != nil {
+			return err
+		}
+		if err := json.Unmarshal(tuple[2], &nai.Nai); err != nil {
+			return err
+		}
+	}
+	parsed, err := nai.asset()
+	if err != nil {
+		return err
+	}
+	*a = parsed
+	return nil
+}
+
+// An asset that marshals to the HF26 object form
+// {"amount": "1000", "precision": 3, "nai": "@@000000021"}
+type NaiAsset Asset
+
+func (a NaiAsset) MarshalJSON() ([]byte, error) {
+	if Asset(a) == (Asset{}) {
+		return []byte("null"), nil
+	}
+	info, ok := assets[a.Symbol]
+	if !ok {
+		return nil, fmt.Errorf("unknown asset symbol %q", a.Symbol)
+	}
+	return json.Marshal(naiAsset{strconv.FormatInt(a.Amount, 10), int(a.Precision), info.nai})
+}
+
+func (a *NaiAsset) UnmarshalJSON(b []byte) error {
+	return (*Asset)(a).UnmarshalJSON(b)
+}
+
+// Asset in the NAI object form returned by the appbase APIs
+type naiAsset struct {
+	Amount    string `json:"amount"`
+	Precision int    `json:"precision"`
+	Nai       string `json:"nai"`
+}
+
+// Returns the asset with its mainnet symbol, see Asset.OnChain
+func (a naiAsset) asset() (Asset, error) {
+	var symbol string
+	for _, s := range naiSymbols(false) {
+		if assets[s].nai == a.Nai {
+			symbol = s
+		}
+	}
+	if symbol == "" {
+		return Asset{}, errors.New("unknown asset nai: " + a.Nai)
+	}
+
+	amount, err := strconv.ParseInt(a.Amount, 10, 64)
+	if err != nil {
+		return Asset{}, err
+	}
+	asset, _ := NewAsset(amount, symbol)
+	if int(asset.Precision) != a.Precision {
+		return Asset{}, fmt.Errorf("%s has precision %d, got %d", symbol, asset.Precision, a.Precision)
+	}
+	return asset, nil
+}
+
+// Formats an amount in the smallest unit as a legacy asset string
+func formatLegacyAsset(amount int64, precision int, symbol string) string {
+	sign := ""
+	abs := uint64(amount)
+	if amount < 0 {
+		sign = "-"
+		abs = uint64(-amount)
+	}
+	digits := strconv.FormatUint(abs, 10)
+	if len(digits) <= precision {
+		digits = strings.Repeat("0", precision-len(digits)+1) + digits
+	}
+	whole, decimals := digits[:len(digits)-precision], digits[len(digits)-precision:]
+	if precision == 0 {
+		return sign + whole + " " + symbol
+	}
+	return sign + whole + "." + decimals + " " + symbol
+}
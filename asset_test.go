@@ -0,0 +1,190 @@
+package hivego
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+)
+
+func TestParseAsset(t *testing.T) {
+	cases := []struct {
+		in       string
+		expected Asset
+	}{
+		{"1.000 HIVE", Asset{1000, 3, "HIVE"}},
+		{"0.5 HBD", Asset{500, 3, "HBD"}},
+		{"12 HIVE", Asset{12000, 3, "HIVE"}},
+		{"-1.500 HBD", Asset{-1500, 3, "HBD"}},
+		{"1.234567 VESTS", Asset{1234567, 6, "VESTS"}},
+		{"3.000 TESTS", Asset{3000, 3, "TESTS"}},
+	}
+	for _, c := range cases {
+		got, err := ParseAsset(c.in)
+		if err != nil {
+			t.Error(c.in, err)
+			continue
+		}
+		if got != c.expected {
+			t.Error("Expected", c.expected, "got", got)
+		}
+	}
+
+	for _, invalid := range []string{"1.0000 HIVE", "1.000 STEEM", "1.000HIVE", "HIVE", ".5 HIVE", "1.0a0 HIVE", "1.000  HIVE", "99999999999999999999 HIVE"} {
+		if _, err := ParseAsset(invalid); err == nil {
+			t.Error("Expected an error for", invalid)
+		}
+	}
+}
+
+func TestAssetString(t *testing.T) {
+	cases := []struct {
+		asset    Asset
+		expected string
+	}{
+		{Asset{1000, 3, "HIVE"}, "1.000 HIVE"},
+		{Asset{5, 6, "VESTS"}, "0.000005 VESTS"},
+		{Asset{-1500, 3, "HBD"}, "-1.500 HBD"},
+		{Asset{math.MinInt64, 3, "HBD"}, "-9223372036854775.808 HBD"},
+	}
+	for _, c := range cases {
+		if got := c.asset.String(); got != c.expected {
+			t.Error("Expected", c.expected, "got", got)
+		}
+	}
+}
+
+func TestAssetArithmetic(t *testing.T) {
+	a := MustParseAsset("1.500 HIVE")
+	b := MustParseAsset("0.250 HIVE")
+
+	sum, err := a.Add(b)
+	if err != nil || sum.String() != "1.750 HIVE" {
+		t.Error("Expected 1.750 HIVE, got", sum, err)
+	}
+	diff, err := b.Sub(a)
+	if err != nil || diff.String() != "-1.250 HIVE" {
+		t.Error("Expected -1.250 HIVE, got", diff, err)
+	}
+	if cmp, err := a.Cmp(b); err != nil || cmp != 1 {
+		t.Error("Expected 1, got", cmp, err)
+	}
+	if cmp, err := b.Cmp(a); err != nil || cmp != -1 {
+		t.Error("Expected -1, got", cmp, err)
+	}
+	if cmp, err := a.Cmp(a); err != nil || cmp != 0 {
+		t.Error("Expected 0, got", cmp, err)
+	}
+
+	if _, err := a.Add(MustParseAsset("1.000 HBD")); err == nil {
+		t.Error("Expected an error adding HIVE and HBD")
+	}
+	if _, err := a.Cmp(MustParseAsset("1.000000 VESTS")); err == nil {
+		t.Error("Expected an error comparing HIVE and VESTS")
+	}
+	if _, err := (Asset{math.MaxInt64, 3, "HIVE"}).Add(Asset{1, 3, "HIVE"}); err == nil {
+		t.Error("Expected an overflow error")
+	}
+	if _, err := (Asset{math.MinInt64, 3, "HIVE"}).Sub(Asset{1, 3, "HIVE"}); err == nil {
+		t.Error("Expected an overflow error")
+	}
+}
+
+func TestAssetJSON(t *testing.T) {
+	asset := MustParseAsset("1.234 HBD")
+
+	legacy, err := json.Marshal(asset)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(legacy) != `"1.234 HBD"` {
+		t.Error("Expected legacy form, got", string(legacy))
+	}
+	nai, err := json.Marshal(NaiAsset(asset))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `{"amount":"1234","precision":3,"nai":"@@000000013"}`
+	if string(nai) != expected {
+		t.Error("Expected", expected, "got", string(nai))
+	}
+
+	for _, in := range []string{string(legacy), string(nai), `["1234", 3, "@@000000013"]`} {
+		var got Asset
+		if err := json.Unmarshal([]byte(in), &got); err != nil {
+			t.Error(in, err)
+			continue
+		}
+		if got != asset {
+			t.Error("Expected", asset, "got", got)
+		}
+	}
+
+	for _, invalid := range []string{`"1.2345 HBD"`, `{"amount":"1","precision":6,"nai":"@@000000013"}`, `{"amount":"1","precision":3,"nai":"@@000000000"}`, `12`} {
+		var got Asset
+		if err := json.Unmarshal([]byte(invalid), &got); err == nil {
+			t.Error("Expected an error for", invalid)
+		}
+	}
+
+	if _, err := json.Marshal(Asset{1, 3, "STEEM"}); err == nil {
+		t.Error("Expected an error for an unknown symbol")
+	}
+}
+
+func TestAssetTestnetSymbols(t *testing.T) {
+	testnet := "18dcf0a285365fc58b71f18b3d3fec954aa0c141c44e4e5cb4cf777b9eab274e"
+
+	var got Asset
+	if err := json.Unmarshal([]byte(`{"amount":"1000","precision":3,"nai":"@@000000021"}`), &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.OnChain(testnet).String() != "1.000 TESTS" || got.OnChain("").String() != "1.000 HIVE" {
+		t.Error("Unexpected symbols", got.OnChain(testnet), got.OnChain(""))
+	}
+
+	op := TransferOperation{From: "xeroc", To: "bob", Amount: MustParseAsset("1.000 TBD")}
+	b, _ := op.SerializeOp()
+	decoded, err := DeserializeOp(b, testnet)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if decoded != op {
+		t.Error("Expected", op, "got", decoded)
+	}
+	if decoded, _ := DeserializeOp(b); decoded.(TransferOperation).Amount.Symbol != "HBD" {
+		t.Error("Expected the mainnet symbol without a chain id, got", decoded)
+	}
+
+	// broadcast as legacy JSON with the testnet symbols, leaving op untouched
+	mainnetOp := TransferOperation{From: "xeroc", To: "bob", Amount: MustParseAsset("1.000 HBD")}
+	tx := getTestTx([]HiveOperation{mainnetOp, FeedPublishOperation{"xeroc", Price{MustParseAsset("0.300 HBD"), MustParseAsset("1.000 HIVE")}}})
+	tx.prepareJson(testnet)
+	jsonB, _ := json.Marshal(tx.OperationsJs)
+	expected := `[["transfer",{"from":"xeroc","to":"bob","amount":"1.000 TBD","memo":""}],["feed_publish",{"publisher":"xeroc","exchange_rate":{"base":"0.300 TBD","quote":"1.000 TESTS"}}]]`
+	if string(jsonB) != expected {
+		t.Error("Expected", expected, "got", string(jsonB))
+	}
+	if tx.Operations[0].(TransferOperation).Amount.Symbol != "HBD" {
+		t.Error("prepareJson changed the caller's operation")
+	}
+}
+
+func TestAssetJSONUnset(t *testing.T) {
+	jsonB, err := json.Marshal(struct {
+		Fee Asset    `json:"fee"`
+		Nai NaiAsset `json:"nai"`
+	}{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(jsonB) != `{"fee":null,"nai":null}` {
+		t.Error("Unexpected JSON", string(jsonB))
+	}
+	var got Asset
+	if err := json.Unmarshal([]byte("null"), &got); err != nil || got != (Asset{}) {
+		t.Error("Expected null to leave the asset unset, got", got, err)
+	}
+	if _, err := json.Marshal(Asset{1, 3, "STEEM"}); err == nil {
+		t.Error("Expected an error for a set but unknown symbol")
+	}
+}
@@ -0,0 +1,192 @@
+package hivego
+
+import (
+	"fmt"
+)
+
+type AuthorityLevel string
+
+const (
+	AuthorityPosting AuthorityLevel = "posting"
+	AuthorityActive  AuthorityLevel = "active"
+	AuthorityOwner   AuthorityLevel = "owner"
+)
+
+// HIVE_MAX_SIG_CHECK_DEPTH: how deep account_auths are followed
+const maxSigCheckDepth = 2
+
+// Outcome of an authority check
+type AuthorityCheck struct {
+	Satisfied bool
+	// weight reached by the keys at the top level and its threshold
+	Weight    uint32
+	Threshold uint32
+	// weight still needed to reach the threshold, 0 when satisfied
+	Missing uint32
+}
+
+// Checks whether public keys satisfy an account authority the way the chain
+// does: key_auths count with their weight, account_auths count if the keys
+// satisfy that account's authority, up to the chain's depth limit. Owner and
+// active authorities are satisfied by the active authority of the accounts
+// they list, posting authorities by their posting authority. Like the chain,
+// a posting authority is also satisfied by the account's active or owner
+// authority and an active authority by its owner authority.
+//
+// Fetched accounts are cached, a checker is meant for one check or a few
+// close together.
+type AuthorityChecker struct {
+	fetch    func(accountNames []string) ([]AccountData, error)
+	accounts map[string]AccountData
+}
+
+// Returns a checker fetching accounts with fetch, usually GetAccount of a
+// node
+func NewAuthorityChecker(fetch func(accountNames []string) ([]AccountData, error)) *AuthorityChecker {
+	return &AuthorityChecker{fetch, map[string]AccountData{}}
+}
+
+// Checks whether keys satisfy the authority of account at the given level
+func (h *HiveRpcNode) CheckAuthority(account string, level AuthorityLevel, keys []string) (AuthorityCheck, error) {
+	return NewAuthorityChecker(h.GetAccount).Check(account, level, keys)
+}
+
+// Checks whether keys satisfy the authority of account at the given level.
+// When neither level satisfies it, the weight reported is the one of the
+// requested level.
+func (c *AuthorityChecker) Check(account string, level AuthorityLevel, keys []string) (AuthorityCheck, error) {
+	keySet := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		keySet[key] = true
+	}
+
+	var levels []AuthorityLevel
+	switch level {
+	case AuthorityPosting:
+		levels = []AuthorityLevel{AuthorityPosting, AuthorityActive, AuthorityOwner}
+	case AuthorityActive:
+		levels = []AuthorityLevel{AuthorityActive, AuthorityOwner}
+	default:
+		levels = []AuthorityLevel{level}
+	}
+
+	var requested AuthorityCheck
+	for i, l := range levels {
+		check, err := c.check(account, l, keySet)
+		if err != nil {
+			return AuthorityCheck{}, err
+		}
+		if check.Satisfied {
+			return check, nil
+		}
+		if i == 0 {
+			requested = check
+		}
+	}
+	return requested, nil
+}
+
+func (c *AuthorityChecker) check(account string, level AuthorityLevel, keys map[string]bool) (AuthorityCheck, error) {
+	auth, err := c.authority(account, level)
+	if err != nil {
+		return AuthorityCheck{}, err
+	}
+
+	nestedLevel := level
+	if level == AuthorityOwner {
+		nestedLevel = AuthorityActive
+	}
+	weight, err := c.weight(auth, nestedLevel, keys, 0)
+	if err != nil {
+		return AuthorityCheck{}, err
+	}
+
+	check := AuthorityCheck{
+		Satisfied: weight >= auth.Threshold,
+		Weight:    weight,
+		Threshold: auth.Threshold,
+	}
+	if !check.Satisfied {
+		check.Missing = auth.Threshold - weight
+	}
+	return check, nil
+}
+
+// Checks whether the signatures of tx satisfy the authority of account at
+// the given level. chainId may be empty for mainnet. Like the chain, the
+// signatures may be over the HF26 or the legacy serialization.
+func (c *AuthorityChecker) CheckSignatures(account string, level AuthorityLevel, tx HiveTransaction, chainId string) (AuthorityCheck, error) {
+	hf26, legacy, err := tx.SignerKeysBySerialization(chainId)
+	if err != nil {
+		return AuthorityCheck{}, err
+	}
+	check, err := c.Check(account, level, hf26)
+	if err != nil || check.Satisfied || legacy == nil {
+		return check, err
+	}
+	legacyCheck, err := c.Check(account, level, legacy)
+	if err != nil || !legacyCheck.Satisfied {
+		return check, err
+	}
+	return legacyCheck, nil
+}
+
+// Returns the weight keys reach in auth. Stops counting once the threshold
+// is reached, like the chain.
+func (c *AuthorityChecker) weight(auth Authority, nestedLevel AuthorityLevel, keys map[string]bool, depth int) (uint32, error) {
+	var weight uint32
+	for _, keyAuth := range auth.KeyAuths {
+		if keys[keyAuth.Key] {
+			weight += uint32(keyAuth.Weight)
+			if weight >= auth.Threshold {
+				return weight, nil
+			}
+		}
+	}
+	if depth == maxSigCheckDepth {
+		return weight, nil
+	}
+
+	for _, accountAuth := range auth.AccountAuths {
+		nested, err := c.authority(accountAuth.Account, nestedLevel)
+		if err != nil {
+			return 0, err
+		}
+		nestedWeight, err := c.weight(nested, nestedLevel, keys, depth+1)
+		if err != nil {
+			return 0, err
+		}
+		if nestedWeight >= nested.Threshold {
+			weight += uint32(accountAuth.Weight)
+			if weight >= auth.Threshold {
+				return weight, nil
+			}
+		}
+	}
+	return weight, nil
+}
+
+func (c *AuthorityChecker) authority(account string, level AuthorityLevel) (Authority, error) {
+	data, ok := c.accounts[account]
+	if !ok {
+		accounts, err := c.fetch([]string{account})
+		if err != nil {
+			return Authority{}, err
+		}
+		if len(accounts) == 0 || accounts[0].Name != account {
+			return Authority{}, fmt.Errorf("account %s not found", account)
+		}
+		data = accounts[0]
+		c.accounts[account] = data
+	}
+
+	switch level {
+	case AuthorityPosting:
+		return data.Posting, nil
+	case AuthorityActive:
+		return data.Active, nil
+	case AuthorityOwner:
+		return data.Owner, nil
+	}
+	return Authority{}, fmt.Errorf("unknown authority level %q", level)
+}
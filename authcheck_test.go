@@ -0,0 +1,165 @@
+package hivego
+
+import (
+	"encoding/hex"
+	"errors"
+	"testing"
+)
+
+func getTestAuthorityChecker(fetched *[]string) *AuthorityChecker {
+	key := func(account string) string {
+		return *KeyPairFromPassword(account, "active", "pass").GetPublicKeyString()
+	}
+	accounts := map[string]AccountData{
+		// 2 of 3: a key, alice and the bob -> carol -> dave chain
+		"gateway": {Name: "gateway", Active: Authority{
+			Threshold:    2,
+			AccountAuths: []AccountAuth{{"alice", 1}, {"bob", 1}},
+			KeyAuths:     []KeyAuth{{key("gateway"), 1}},
+		}},
+		"alice": {Name: "alice", Active: NewKeyAuthority(key("alice")), Posting: NewKeyAuthority(key("alice-posting"))},
+		"bob":   {Name: "bob", Active: Authority{Threshold: 1, AccountAuths: []AccountAuth{{"carol", 1}}}, Posting: NewKeyAuthority(key("bob-posting"))},
+		"carol": {Name: "carol", Active: Authority{Threshold: 1, AccountAuths: []AccountAuth{{"dave", 1}}, KeyAuths: []KeyAuth{{key("carol"), 1}}}},
+		"dave":  {Name: "dave", Active: NewKeyAuthority(key("dave"))},
+	}
+	accounts["gateway-owner"] = AccountData{Name: "gateway-owner", Owner: accounts["gateway"].Active}
+	accounts["gateway-posting"] = AccountData{Name: "gateway-posting", Posting: accounts["gateway"].Active}
+	for name, account := range accounts {
+		owner := NewKeyAuthority(*KeyPairFromPassword(name, "owner", "pass").GetPublicKeyString())
+		if account.Owner.Threshold == 0 {
+			account.Owner = owner
+		}
+		if account.Active.Threshold == 0 {
+			account.Active = owner
+		}
+		if account.Posting.Threshold == 0 {
+			account.Posting = owner
+		}
+		accounts[name] = account
+	}
+
+	return NewAuthorityChecker(func(names []string) ([]AccountData, error) {
+		*fetched = append(*fetched, names...)
+		if names[0] == "offline" {
+			return nil, errors.New("node unavailable")
+		}
+		if account, ok := accounts[names[0]]; ok {
+			return []AccountData{account}, nil
+		}
+		return []AccountData{}, nil
+	})
+}
+
+func TestAuthorityChecker(t *testing.T) {
+	key := func(account string) string {
+		return *KeyPairFromPassword(account, "active", "pass").GetPublicKeyString()
+	}
+	owner := func(account string) string {
+		return *KeyPairFromPassword(account, "owner", "pass").GetPublicKeyString()
+	}
+	cases := []struct {
+		account  string
+		level    AuthorityLevel
+		keys     []string
+		expected AuthorityCheck
+	}{
+		{"gateway", AuthorityActive, []string{key("gateway"), key("alice")}, AuthorityCheck{true, 2, 2, 0}},
+		{"gateway", AuthorityActive, []string{key("alice")}, AuthorityCheck{false, 1, 2, 1}},
+		{"gateway", AuthorityActive, []string{}, AuthorityCheck{false, 0, 2, 2}},
+		// carol is at depth 2, where her key still counts
+		{"gateway", AuthorityActive, []string{key("alice"), key("carol")}, AuthorityCheck{true, 2, 2, 0}},
+		// dave would be at depth 3, beyond the chain's limit
+		{"gateway", AuthorityActive, []string{key("alice"), key("dave")}, AuthorityCheck{false, 1, 2, 1}},
+		// owner account_auths are satisfied by active authorities
+		{"gateway-owner", AuthorityOwner, []string{key("gateway"), key("alice")}, AuthorityCheck{true, 2, 2, 0}},
+		// posting account_auths by posting authorities
+		{"gateway-posting", AuthorityPosting, []string{key("gateway"), key("alice")}, AuthorityCheck{false, 1, 2, 1}},
+		{"gateway-posting", AuthorityPosting, []string{key("gateway"), key("alice-posting")}, AuthorityCheck{true, 2, 2, 0}},
+		// like the chain, higher authorities satisfy lower ones
+		{"gateway", AuthorityActive, []string{owner("gateway")}, AuthorityCheck{true, 1, 1, 0}},
+		{"alice", AuthorityPosting, []string{key("alice")}, AuthorityCheck{true, 1, 1, 0}},
+		{"alice", AuthorityPosting, []string{owner("alice")}, AuthorityCheck{true, 1, 1, 0}},
+		// but not the other way around, and a failed check reports the requested level
+		{"alice", AuthorityOwner, []string{key("alice")}, AuthorityCheck{false, 0, 1, 1}},
+		{"alice", AuthorityActive, []string{key("alice-posting")}, AuthorityCheck{false, 0, 1, 1}},
+	}
+	for _, c := range cases {
+		var fetched []string
+		got, err := getTestAuthorityChecker(&fetched).Check(c.account, c.level, c.keys)
+		if err != nil {
+			t.Error(c.account, err)
+			continue
+		}
+		if got != c.expected {
+			t.Error(c.account, c.keys, "expected", c.expected, "got", got)
+		}
+	}
+
+	var fetched []string
+	checker := getTestAuthorityChecker(&fetched)
+	checker.Check("gateway", AuthorityActive, []string{key("dave")})
+	checker.Check("gateway", AuthorityActive, []string{key("carol")})
+	if len(fetched) != 4 {
+		t.Error("Expected the 4 accounts to be fetched once, got", fetched)
+	}
+	if _, err := checker.Check("nobody", AuthorityActive, nil); err == nil {
+		t.Error("Expected an error for a missing account")
+	}
+	if _, err := checker.Check("offline", AuthorityActive, nil); err == nil {
+		t.Error("Expected the fetch error")
+	}
+}
+
+func TestAuthorityCheckerSignatures(t *testing.T) {
+	var fetched []string
+	checker := getTestAuthorityChecker(&fetched)
+	tx := getTestVoteTx()
+	message, _ := SerializeTx(tx)
+	for _, account := range []string{"gateway", "alice"} {
+		wif := KeyPairFromPassword(account, "active", "pass").ToWif()
+		sig, _ := SignDigest(HashTxForSig(message), &wif)
+		tx.AddSig(hex.EncodeToString(sig))
+	}
+
+	got, err := checker.CheckSignatures("gateway", AuthorityActive, tx, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !got.Satisfied {
+		t.Error("Expected the signatures to satisfy the authority, got", got)
+	}
+
+	// the signatures are for mainnet and recover to other keys on a testnet
+	got, err = checker.CheckSignatures("gateway", AuthorityActive, tx, "18dcf0a285365fc58b71f18b3d3fec954aa0c141c44e4e5cb4cf777b9eab274e")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Satisfied {
+		t.Error("Expected the testnet check to fail, got", got)
+	}
+}
+
+func TestAuthorityCheckerLegacySignatures(t *testing.T) {
+	var fetched []string
+	checker := getTestAuthorityChecker(&fetched)
+	tx := getTestTx([]HiveOperation{TransferOperation{From: "gateway", To: "bob", Amount: MustParseAsset("1.000 HIVE")}})
+	message, _ := SerializeTx(tx)
+	// signed over the legacy serialization, like most tools do
+	legacy, err := legacySerialization(message, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, account := range []string{"gateway", "alice"} {
+		wif := KeyPairFromPassword(account, "active", "pass").ToWif()
+		sig, _ := SignDigest(HashTxForSig(legacy), &wif)
+		tx.AddSig(hex.EncodeToString(sig))
+	}
+
+	got, err := checker.CheckSignatures("gateway", AuthorityActive, tx, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !got.Satisfied {
+		t.Error("Expected the legacy signatures to satisfy the authority, got", got)
+	}
+}
@@ -0,0 +1,142 @@
+package hivego
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// An account authority: the accounts and keys allowed to sign for it and the
+// combined weight they need to reach
+type Authority struct {
+	Threshold    uint32        `json:"weight_threshold"`
+	AccountAuths []AccountAuth `json:"account_auths"`
+	KeyAuths     []KeyAuth     `json:"key_auths"`
+}
+
+// JSON tuple [account, weight]
+type AccountAuth struct {
+	Account string
+	Weight  uint16
+}
+
+// JSON tuple [public key, weight]
+type KeyAuth struct {
+	Key    string
+	Weight uint16
+}
+
+// Authority satisfied by a single public key
+func NewKeyAuthority(pubKey string) Authority {
+	return Authority{
+		Threshold:    1,
+		AccountAuths: []AccountAuth{},
+		KeyAuths:     []KeyAuth{{pubKey, 1}},
+	}
+}
+
+// Nil auths marshal as [] rather than null, which the chain rejects
+func (a Authority) MarshalJSON() ([]byte, error) {
+	type authority Authority
+	if a.AccountAuths == nil {
+		a.AccountAuths = []AccountAuth{}
+	}
+	if a.KeyAuths == nil {
+		a.KeyAuths = []KeyAuth{}
+	}
+	return json.Marshal(authority(a))
+}
+
+func (a AccountAuth) MarshalJSON() ([]byte, error) {
+	return json.Marshal([2]interface{}{a.Account, a.Weight})
+}
+
+func (a *AccountAuth) UnmarshalJSON(b []byte) error {
+	return unmarshalAuthTuple(b, &a.Account, &a.Weight)
+}
+
+func (k KeyAuth) MarshalJSON() ([]byte, error) {
+	return json.Marshal([2]interface{}{k.Key, k.Weight})
+}
+
+func (k *KeyAuth) UnmarshalJSON(b []byte) error {
+	return unmarshalAuthTuple(b, &k.Key, &k.Weight)
+}
+
+func unmarshalAuthTuple(b []byte, name *string, weight *uint16) error {
+	var tuple []json.RawMessage
+	if err := json.Unmarshal(b, &tuple); err != nil {
+		return err
+	}
+	if len(tuple) != 2 {
+		return fmt.Errorf("invalid authority entry %s", string(b))
+	}
+	if err := json.Unmarshal(tuple[0], name); err != nil {
+		return err
+	}
+	return json.Unmarshal(tuple[1], weight)
+}
+
+// Checks that the authority can be satisfied at all. Broadcasting an
+// impossible owner authority would lock the account.
+func (a Authority) Validate() error {
+	if a.Threshold == 0 {
+		return errors.New("authority threshold must be greater than 0")
+	}
+	var total uint64
+	for _, auth := range a.AccountAuths {
+		total += uint64(auth.Weight)
+	}
+	for _, auth := range a.KeyAuths {
+		total += uint64(auth.Weight)
+	}
+	if total < uint64(a.Threshold) {
+		return fmt.Errorf("authority weights add up to %d, below the threshold of %d", total, a.Threshold)
+	}
+	return nil
+}
+
+// Returns a copy of the account auths in the chain's order (by name),
+// failing on duplicates
+func (a Authority) sortedAccountAuths() ([]AccountAuth, error) {
+	auths := append([]AccountAuth{}, a.AccountAuths...)
+	sort.Slice(auths, func(i, j int) bool {
+		return auths[i].Account < auths[j].Account
+	})
+	for i := 1; i < len(auths); i++ {
+		if auths[i].Account == auths[i-1].Account {
+			return nil, fmt.Errorf("duplicate account %s in authority", auths[i].Account)
+		}
+	}
+	return auths, nil
+}
+
+type serializedKeyAuth struct {
+	pubKey string
+	key    []byte
+	weight uint16
+}
+
+// Returns the key auths as compressed keys in the chain's order, which
+// compares the key bytes and not the base58 strings, failing on duplicates
+func (a Authority) sortedKeyAuths() ([]serializedKeyAuth, error) {
+	auths := make([]serializedKeyAuth, 0, len(a.KeyAuths))
+	for _, auth := range a.KeyAuths {
+		pk, err := DecodePublicKey(auth.Key)
+		if err != nil {
+			return nil, fmt.Errorf("invalid key %s in authority: %w", auth.Key, err)
+		}
+		auths = append(auths, serializedKeyAuth{auth.Key, pk.SerializeCompressed(), auth.Weight})
+	}
+	sort.Slice(auths, func(i, j int) bool {
+		return bytes.Compare(auths[i].key, auths[j].key) < 0
+	})
+	for i := 1; i < len(auths); i++ {
+		if bytes.Equal(auths[i].key, auths[i-1].key) {
+			return nil, fmt.Errorf("duplicate key %s in authority", auths[i].pubKey)
+		}
+	}
+	return auths, nil
+}
@@ -0,0 +1,99 @@
+package hivego
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+)
+
+func TestAuthorityJson(t *testing.T) {
+	raw := `{"weight_threshold":2,"account_auths":[["bob",1]],"key_auths":[["` + testPubKey + `",1]]}`
+	var auth Authority
+	if err := json.Unmarshal([]byte(raw), &auth); err != nil {
+		t.Fatal(err)
+	}
+	if auth.Threshold != 2 || auth.AccountAuths[0] != (AccountAuth{"bob", 1}) || auth.KeyAuths[0] != (KeyAuth{testPubKey, 1}) {
+		t.Fatal("Unexpected authority", auth)
+	}
+
+	jsonB, _ := json.Marshal(auth)
+	if string(jsonB) != raw {
+		t.Error("Expected", raw, "got", string(jsonB))
+	}
+}
+
+func TestAuthorityJsonNilAuths(t *testing.T) {
+	auth := Authority{Threshold: 1, KeyAuths: []KeyAuth{{testPubKey, 1}}}
+	jsonB, err := json.Marshal(auth)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `{"weight_threshold":1,"account_auths":[],"key_auths":[["` + testPubKey + `",1]]}`
+	if string(jsonB) != expected {
+		t.Error("Expected", expected, "got", string(jsonB))
+	}
+
+	jsonB, _ = json.Marshal(AccountUpdateOperation{Account: "xeroc", Owner: &Authority{Threshold: 1}})
+	expected = `{"account":"xeroc","owner":{"weight_threshold":1,"account_auths":[],"key_auths":[]},"active":null,"posting":null,"memo_key":"","json_metadata":""}`
+	if string(jsonB) != expected {
+		t.Error("Expected", expected, "got", string(jsonB))
+	}
+}
+
+func TestSerializeAuthorityCanonicalOrder(t *testing.T) {
+	other := *KeyPairFromPassword("alice", "active", "pass").GetPublicKeyString()
+	auth := Authority{
+		Threshold:    1,
+		AccountAuths: []AccountAuth{{"carol", 1}, {"bob", 2}},
+		KeyAuths:     []KeyAuth{{testPubKey, 1}, {other, 1}},
+	}
+	var got bytes.Buffer
+	if err := serializeAuthority(auth, &got); err != nil {
+		t.Fatal(err)
+	}
+
+	otherPk, _ := DecodePublicKey(other)
+	keys := [][]byte{testPubKeyBytes, otherPk.SerializeCompressed()}
+	if bytes.Compare(keys[0], keys[1]) > 0 {
+		keys[0], keys[1] = keys[1], keys[0]
+	}
+	expected := []byte{1, 0, 0, 0, 2, 3, 98, 111, 98, 2, 0, 5, 99, 97, 114, 111, 108, 1, 0, 2}
+	expected = append(append(expected, keys[0]...), 1, 0)
+	expected = append(append(expected, keys[1]...), 1, 0)
+	if !bytes.Equal(got.Bytes(), expected) {
+		t.Error("Expected", expected, "got", got.Bytes())
+	}
+	// the caller's authority is left untouched
+	if auth.AccountAuths[0].Account != "carol" {
+		t.Error("serializeAuthority reordered the caller's account auths")
+	}
+}
+
+func TestSerializeAuthorityErrors(t *testing.T) {
+	auths := []Authority{
+		{Threshold: 1, AccountAuths: []AccountAuth{{"bob", 1}, {"bob", 1}}},
+		{Threshold: 1, KeyAuths: []KeyAuth{{testPubKey, 1}, {testPubKey, 1}}},
+		{Threshold: 1, KeyAuths: []KeyAuth{{"STMinvalid", 1}}},
+	}
+	for _, auth := range auths {
+		var buf bytes.Buffer
+		if err := serializeAuthority(auth, &buf); err == nil {
+			t.Error("Expected an error serializing", auth)
+		}
+	}
+
+	op := AccountUpdateOperation{Account: "xeroc", Owner: &auths[0], MemoKey: testPubKey}
+	if _, err := op.SerializeOp(); err == nil {
+		t.Error("Expected the authority error to reach the caller")
+	}
+}
+
+func TestAuthorityValidate(t *testing.T) {
+	if err := NewKeyAuthority(testPubKey).Validate(); err != nil {
+		t.Error(err)
+	}
+	impossible := Authority{Threshold: 3, AccountAuths: []AccountAuth{{"bob", 1}}, KeyAuths: []KeyAuth{{testPubKey, 1}}}
+	if err := impossible.Validate(); err == nil {
+		t.Error("Expected an error for an impossible authority")
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/binary"
 	"encoding/hex"
 	"encoding/json"
+	"fmt"
 	"log"
 	"time"
 )
@@ -202,7 +203,7 @@ func (h *HiveRpcNode) StreamBlocks() (<-chan Block, error) {
 			return
 		}
 
-		var props globalProps
+		var props GlobalProps
 		err = json.Unmarshal(res, &props)
 		if err != nil {
 			log.Fatalf("Failed to unmarshal dynamic global properties: %v", err)
@@ -365,3 +366,15 @@ func (h *HiveRpcNode) fetchBlock(params []getBlockQueryParams) ([]Block, error)
 	}
 	return processedBlocks, nil
 }
+
+// Decodes the value of a block operation into one of the typed operations
+func decodeOperationValue(op Operation, opType string, v interface{}) error {
+	if op.Type != opType {
+		return fmt.Errorf("expected %s, got %s", opType, op.Type)
+	}
+	b, err := json.Marshal(op.Value)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, v)
+}
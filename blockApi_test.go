@@ -1,6 +1,8 @@
 package hivego
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
 	"testing"
 )
@@ -14,3 +16,27 @@ func TestVirtualOps(t *testing.T) {
 	}
 	fmt.Println(virtualOps)
 }
+
+func TestDecodeCustomBinaryOperation(t *testing.T) {
+	var op Operation
+	err := json.Unmarshal([]byte(`{"type":"custom_binary_operation","value":{"required_owner_auths":[],"required_active_auths":[],"required_posting_auths":["xeroc"],"required_auths":[{"weight_threshold":1,"account_auths":[["bob",1]],"key_auths":[]}],"id":"app","data":"01"}}`), &op)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	custom, err := DecodeCustomBinaryOperation(op)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if custom.Id != "app" || !bytes.Equal(custom.Data, []byte{1}) {
+		t.Error("Unexpected operation", custom)
+	}
+	// decoded authorities must serialize like built ones
+	if _, err := custom.SerializeOp(); err != nil {
+		t.Error(err)
+	}
+
+	if _, err := DecodeCustomOperation(op); err == nil {
+		t.Error("Expected an error decoding a custom_binary_operation as custom_operation")
+	}
+}
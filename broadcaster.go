@@ -42,16 +42,19 @@ func (t *HiveTransaction) Sign(keyPair KeyPair, chainId ...string) (string, erro
 	return hex.EncodeToString(sig), nil
 }
 
+// Appends sig without verifying it, see PartialTransaction for collecting
+// verified signatures
 func (t *HiveTransaction) AddSig(sig string) {
 	t.Signatures = append(t.Signatures, sig)
 }
 
-func (t *HiveTransaction) prepareJson() {
+// Fills OperationsJs, with the asset symbols of the chain of chainId
+func (t *HiveTransaction) prepareJson(chainId string) {
 	var opsContainer [][2]interface{}
 	for _, op := range t.Operations {
 		var opContainer [2]interface{}
 		opContainer[0] = op.OpName()
-		opContainer[1] = op
+		opContainer[1] = operationOnChain(op, chainId)
 		opsContainer = append(opsContainer, opContainer)
 	}
 	if t.Extensions == nil {
@@ -61,6 +64,15 @@ func (t *HiveTransaction) prepareJson() {
 }
 
 func (h *HiveRpcNode) Broadcast(ops []HiveOperation, wif *string) (string, error) {
+	return h.BroadcastMultiSig(ops, []*string{wif})
+}
+
+// Broadcast operations signed with several keys, for transactions that need
+// more than one authority such as recover_account
+func (h *HiveRpcNode) BroadcastMultiSig(ops []HiveOperation, wifs []*string) (string, error) {
+	if len(wifs) == 0 {
+		return "", fmt.Errorf("at least one key is required to sign")
+	}
 	signingData, err := h.GetSigningData()
 	if err != nil {
 		return "", err
@@ -84,20 +96,17 @@ func (h *HiveRpcNode) Broadcast(ops []HiveOperation, wif *string) (string, error
 	if err != nil {
 		return "", err
 	}
-	sig, err := SignDigest(digest, wif)
-	if err != nil {
-		return "", err
-	}
-
-	tx.Signatures = append(tx.Signatures, hex.EncodeToString(sig))
+	for _, wif := range wifs {
+		sig, err := SignDigest(digest, wif)
+		if err != nil {
+			return "", err
+		}
 
-	tx.prepareJson()
+		tx.Signatures = append(tx.Signatures, hex.EncodeToString(sig))
+	}
 
-	var params []interface{}
-	params = append(params, tx)
 	if !h.NoBroadcast {
-		q := hrpcQuery{"condenser_api.broadcast_transaction", params}
-		res, err := h.rpcExec(q)
+		res, err := h.broadcastTx(tx)
 		if err != nil {
 			return string(res), err
 		}
@@ -111,12 +120,8 @@ func (h *HiveRpcNode) BroadcastRaw(tx HiveTransaction) (string, error) {
 		return "", fmt.Errorf("transaction is not signed")
 	}
 
-	tx.prepareJson()
-	var params []interface{}
-	params = append(params, tx)
 	if !h.NoBroadcast {
-		q := hrpcQuery{"condenser_api.broadcast_transaction", params}
-		res, err := h.rpcExec(q)
+		res, err := h.broadcastTx(tx)
 		if err != nil {
 			return string(res), err
 		}
@@ -127,3 +132,28 @@ func (h *HiveRpcNode) BroadcastRaw(tx HiveTransaction) (string, error) {
 	}
 	return txId, nil
 }
+
+// Returns the query broadcasting tx: HF26 JSON through network_broadcast_api
+// if the node has UseNetworkBroadcast set, legacy JSON through condenser_api
+// otherwise
+func (h *HiveRpcNode) broadcastQuery(tx HiveTransaction) (hrpcQuery, error) {
+	if h.UseNetworkBroadcast {
+		hf26Tx, err := tx.hf26()
+		if err != nil {
+			return hrpcQuery{}, err
+		}
+		params := map[string]interface{}{"trx": hf26Tx, "max_block_age": -1}
+		return hrpcQuery{"network_broadcast_api.broadcast_transaction", params}, nil
+	}
+
+	tx.prepareJson(h.ChainID)
+	return hrpcQuery{"condenser_api.broadcast_transaction", []interface{}{tx}}, nil
+}
+
+func (h *HiveRpcNode) broadcastTx(tx HiveTransaction) ([]byte, error) {
+	q, err := h.broadcastQuery(tx)
+	if err != nil {
+		return nil, err
+	}
+	return h.rpcExec(q)
+}
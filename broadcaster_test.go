@@ -13,7 +13,7 @@ func TestGenerateTrxIdHiveTransaction(t *testing.T) {
 
 func TestPrepareJsonHiveTransaction(t *testing.T) {
 	tx := getTestVoteTx()
-	tx.prepareJson()
+	tx.prepareJson("")
 	got := len(tx.OperationsJs)
 	expected := 1
 
@@ -0,0 +1,98 @@
+package hivego
+
+import (
+	"encoding/json"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Hive rejects permlinks of 256 characters or more
+const maxPermlinkLength = 255
+
+var (
+	permlinkInvalidChars = regexp.MustCompile(`[^a-z0-9-]+`)
+	permlinkDashes       = regexp.MustCompile(`-{2,}`)
+	replyTimestampSuffix = regexp.MustCompile(`-\d{8}t\d{9}z`)
+)
+
+// json_metadata of a post or reply, as understood by the common Hive frontends
+type PostMetadata struct {
+	Tags   []string `json:"tags,omitempty"`
+	App    string   `json:"app,omitempty"`
+	Format string   `json:"format,omitempty"`
+	Image  []string `json:"image,omitempty"`
+}
+
+// Returns the metadata as a JSON string ready for CommentOperation.JsonMetadata
+func (m PostMetadata) String() (string, error) {
+	b, err := json.Marshal(m)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// lowercases tags, drops empty ones and removes duplicates while keeping order
+func (m PostMetadata) normalizedTags() []string {
+	var tags []string
+	seen := make(map[string]bool)
+	for _, tag := range m.Tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag == "" || seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		tags = append(tags, tag)
+	}
+	return tags
+}
+
+// Generates a permlink for a root post from its title. A base36 timestamp is
+// appended so that posts with the same title do not collide.
+func GeneratePermlink(title string) string {
+	return generatePermlink(title, time.Now())
+}
+
+func generatePermlink(title string, now time.Time) string {
+	suffix := strconv.FormatInt(now.UnixNano()/int64(time.Millisecond), 36)
+	slug := slugify(title)
+	if slug == "" {
+		return suffix
+	}
+
+	maxSlug := maxPermlinkLength - len(suffix) - 1
+	if len(slug) > maxSlug {
+		slug = strings.TrimRight(slug[:maxSlug], "-")
+	}
+	return slug + "-" + suffix
+}
+
+// Generates a reply permlink in the same format as hive-js:
+// re-<parent author>-<parent permlink>-<timestamp>
+func GenerateReplyPermlink(parentAuthor string, parentPermlink string) string {
+	return generateReplyPermlink(parentAuthor, parentPermlink, time.Now())
+}
+
+func generateReplyPermlink(parentAuthor string, parentPermlink string, now time.Time) string {
+	timeStr := strings.ToLower(now.UTC().Format("20060102t150405.000z"))
+	timeStr = strings.Replace(timeStr, ".", "", 1)
+
+	// strip the timestamp of a parent that is itself a reply so they don't pile up
+	parentPermlink = replyTimestampSuffix.ReplaceAllString(parentPermlink, "")
+
+	permlink := slugify("re-" + parentAuthor + "-" + parentPermlink)
+	maxPrefix := maxPermlinkLength - len(timeStr) - 1
+	if len(permlink) > maxPrefix {
+		permlink = strings.TrimRight(permlink[:maxPrefix], "-")
+	}
+	return permlink + "-" + timeStr
+}
+
+func slugify(s string) string {
+	s = strings.ToLower(s)
+	s = permlinkInvalidChars.ReplaceAllString(s, "-")
+	s = permlinkDashes.ReplaceAllString(s, "-")
+	return strings.Trim(s, "-")
+}
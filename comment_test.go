@@ -0,0 +1,50 @@
+package hivego
+
+import (
+	"testing"
+	"time"
+)
+
+func TestGeneratePermlink(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	got := generatePermlink("Hello, World! Ünïcode  title", now)
+	expected := "hello-world-n-code-title-loyw3v28"
+	if got != expected {
+		t.Error("Expected", expected, "got", got)
+	}
+
+	got = generatePermlink("", now)
+	expected = "loyw3v28"
+	if got != expected {
+		t.Error("Expected", expected, "got", got)
+	}
+}
+
+func TestGenerateReplyPermlink(t *testing.T) {
+	now := time.Date(2023, 11, 14, 22, 13, 20, 123000000, time.UTC)
+	got := generateReplyPermlink("xeroc", "re-alice-piston-20231114t221000000z", now)
+	expected := "re-xeroc-re-alice-piston-20231114t221320123z"
+	if got != expected {
+		t.Error("Expected", expected, "got", got)
+	}
+}
+
+func TestNewPostOperation(t *testing.T) {
+	op, err := NewPostOperation("xeroc", "Title", "body", PostMetadata{Tags: []string{"Hive", "hive", "go"}, App: "hivego/0.1", Format: "markdown"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if op.ParentPermlink != "hive" {
+		t.Error("Expected parent permlink hive, got", op.ParentPermlink)
+	}
+	expected := `{"tags":["hive","go"],"app":"hivego/0.1","format":"markdown"}`
+	if op.JsonMetadata != expected {
+		t.Error("Expected", expected, "got", op.JsonMetadata)
+	}
+
+	_, err = NewPostOperation("xeroc", "Title", "body", PostMetadata{})
+	if err == nil {
+		t.Error("Expected an error for a post without tags")
+	}
+}
@@ -0,0 +1,149 @@
+package hivego
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+)
+
+type findConversionRequestsParams struct {
+	Account string `json:"account"`
+}
+
+// A pending HBD to HIVE conversion
+type HbdConversionRequest struct {
+	Id             int64
+	Owner          string
+	RequestId      uint32
+	Amount         Asset
+	ConversionDate CustomTime
+}
+
+// A pending HIVE to HBD collateralized conversion
+type CollateralizedConversionRequest struct {
+	Id               int64
+	Owner            string
+	RequestId        uint32
+	CollateralAmount Asset
+	ConvertedAmount  Asset
+	ConversionDate   CustomTime
+}
+
+func (h *HiveRpcNode) GetConversionRequests(owner string) ([]HbdConversionRequest, error) {
+	query := hrpcQuery{
+		method: "database_api.find_hbd_conversion_requests",
+		params: findConversionRequestsParams{Account: owner},
+	}
+	res, err := h.rpcExec(query)
+	if err != nil {
+		return nil, err
+	}
+
+	var response struct {
+		Requests []struct {
+			Id             int64      `json:"id"`
+			Owner          string     `json:"owner"`
+			RequestId      uint32     `json:"requestid"`
+			Amount         Asset      `json:"amount"`
+			ConversionDate CustomTime `json:"conversion_date"`
+		} `json:"requests"`
+	}
+	err = json.Unmarshal(res, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	requests := make([]HbdConversionRequest, 0, len(response.Requests))
+	for _, r := range response.Requests {
+		requests = append(requests, HbdConversionRequest{r.Id, r.Owner, r.RequestId, r.Amount, r.ConversionDate})
+	}
+	return requests, nil
+}
+
+func (h *HiveRpcNode) GetCollateralizedConversionRequests(owner string) ([]CollateralizedConversionRequest, error) {
+	query := hrpcQuery{
+		method: "database_api.find_collateralized_conversion_requests",
+		params: findConversionRequestsParams{Account: owner},
+	}
+	res, err := h.rpcExec(query)
+	if err != nil {
+		return nil, err
+	}
+
+	var response struct {
+		Requests []struct {
+			Id               int64      `json:"id"`
+			Owner            string     `json:"owner"`
+			RequestId        uint32     `json:"requestid"`
+			CollateralAmount Asset      `json:"collateral_amount"`
+			ConvertedAmount  Asset      `json:"converted_amount"`
+			ConversionDate   CustomTime `json:"conversion_date"`
+		} `json:"requests"`
+	}
+	err = json.Unmarshal(res, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	requests := make([]CollateralizedConversionRequest, 0, len(response.Requests))
+	for _, r := range response.Requests {
+		requests = append(requests, CollateralizedConversionRequest{r.Id, r.Owner, r.RequestId, r.CollateralAmount, r.ConvertedAmount, r.ConversionDate})
+	}
+	return requests, nil
+}
+
+// Returns a request id that is not used by any pending conversion of owner,
+// of either kind. Ids handed out by this node are remembered, so concurrent
+// conversions through the same HiveRpcNode never get the same id even before
+// the first one is included in a block.
+func (h *HiveRpcNode) NextConversionRequestId(owner string) (uint32, error) {
+	hbdRequests, err := h.GetConversionRequests(owner)
+	if err != nil {
+		return 0, err
+	}
+	collateralizedRequests, err := h.GetCollateralizedConversionRequests(owner)
+	if err != nil {
+		return 0, err
+	}
+
+	var pendingIds []uint32
+	for _, r := range hbdRequests {
+		pendingIds = append(pendingIds, r.RequestId)
+	}
+	for _, r := range collateralizedRequests {
+		pendingIds = append(pendingIds, r.RequestId)
+	}
+
+	h.convRequestMutex.Lock()
+	defer h.convRequestMutex.Unlock()
+	if h.convRequestIds == nil {
+		h.convRequestIds = make(map[string]uint32)
+	}
+
+	last, reserved := h.convRequestIds[owner]
+	next, err := nextRequestId(pendingIds, last, reserved)
+	if err != nil {
+		return 0, err
+	}
+	h.convRequestIds[owner] = next
+	return next, nil
+}
+
+// Picks the id following the highest pending or previously reserved id.
+// Wrapping around could reuse an id still in flight, so running out of ids
+// is an error.
+func nextRequestId(pendingIds []uint32, lastReserved uint32, reserved bool) (uint32, error) {
+	var next uint64
+	if reserved {
+		next = uint64(lastReserved) + 1
+	}
+	for _, id := range pendingIds {
+		if uint64(id) >= next {
+			next = uint64(id) + 1
+		}
+	}
+	if next > math.MaxUint32 {
+		return 0, errors.New("no conversion request id left above the highest one in use")
+	}
+	return uint32(next), nil
+}
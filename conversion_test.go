@@ -0,0 +1,40 @@
+package hivego
+
+import (
+	"math"
+	"testing"
+)
+
+func TestNextRequestId(t *testing.T) {
+	got, _ := nextRequestId(nil, 0, false)
+	if got != 0 {
+		t.Error("Expected", 0, "got", got)
+	}
+
+	got, _ = nextRequestId([]uint32{3, 1}, 0, false)
+	if got != 4 {
+		t.Error("Expected", 4, "got", got)
+	}
+
+	// an id reserved by this node but not yet on chain must not be reused
+	got, _ = nextRequestId([]uint32{3}, 4, true)
+	if got != 5 {
+		t.Error("Expected", 5, "got", got)
+	}
+
+	got, _ = nextRequestId([]uint32{9}, 4, true)
+	if got != 10 {
+		t.Error("Expected", 10, "got", got)
+	}
+
+	got, err := nextRequestId([]uint32{math.MaxUint32 - 1}, 0, false)
+	if err != nil || got != math.MaxUint32 {
+		t.Error("Expected", uint32(math.MaxUint32), "got", got, err)
+	}
+	if _, err := nextRequestId([]uint32{math.MaxUint32}, 0, false); err == nil {
+		t.Error("Expected an error instead of wrapping to 0")
+	}
+	if _, err := nextRequestId(nil, math.MaxUint32, true); err == nil {
+		t.Error("Expected an error instead of wrapping to 0")
+	}
+}
@@ -0,0 +1,635 @@
+package hivego
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v2"
+)
+
+// Reads the Hive wire format. The first error is kept and every later read
+// returns a zero value, so decoders check err once at the end.
+type opReader struct {
+	b   []byte
+	pos int
+	err error
+	// nais are read as the testnet symbols TESTS and TBD
+	testnet bool
+	// assets read in the HF26 form, see legacySerialization
+	naiAssets []naiAssetOffset
+}
+
+// where the nai of an asset starts and the asset's symbol
+type naiAssetOffset struct {
+	pos    int
+	symbol string
+}
+
+func (r *opReader) next(n int) []byte {
+	if r.err != nil {
+		return make([]byte, n)
+	}
+	if n < 0 || len(r.b)-r.pos < n {
+		r.err = fmt.Errorf("unexpected end of data at byte %d", r.pos)
+		return make([]byte, n)
+	}
+	b := r.b[r.pos : r.pos+n]
+	r.pos += n
+	return b
+}
+
+func (r *opReader) remaining() int {
+	return len(r.b) - r.pos
+}
+
+func (r *opReader) fail(err error) {
+	if r.err == nil {
+		r.err = err
+	}
+}
+
+func (r *opReader) uint8() uint8 {
+	return r.next(1)[0]
+}
+
+func (r *opReader) bool() bool {
+	v := r.uint8()
+	if v > 1 {
+		r.fail(fmt.Errorf("invalid bool value %d", v))
+	}
+	return v == 1
+}
+
+func (r *opReader) uint16() uint16 {
+	return binary.LittleEndian.Uint16(r.next(2))
+}
+
+func (r *opReader) uint32() uint32 {
+	return binary.LittleEndian.Uint32(r.next(4))
+}
+
+func (r *opReader) int64() int64 {
+	return int64(binary.LittleEndian.Uint64(r.next(8)))
+}
+
+func (r *opReader) uvarint() uint64 {
+	if r.err != nil {
+		return 0
+	}
+	v, n := binary.Uvarint(r.b[r.pos:])
+	if n <= 0 {
+		r.fail(fmt.Errorf("invalid varint at byte %d", r.pos))
+		return 0
+	}
+	r.pos += n
+	return v
+}
+
+// reads a varint length, failing if it is larger than the data left so that
+// corrupted input can't trigger huge allocations
+func (r *opReader) length() int {
+	n := r.uvarint()
+	if r.err == nil && n > uint64(r.remaining()) {
+		r.fail(fmt.Errorf("length %d exceeds the data left at byte %d", n, r.pos))
+		return 0
+	}
+	return int(n)
+}
+
+func (r *opReader) vbytes() []byte {
+	return append([]byte{}, r.next(r.length())...)
+}
+
+func (r *opReader) vstring() string {
+	return string(r.next(r.length()))
+}
+
+func (r *opReader) vstringArray() []string {
+	n := r.length()
+	a := make([]string, 0, n)
+	for i := 0; i < n && r.err == nil; i++ {
+		a = append(a, r.vstring())
+	}
+	return a
+}
+
+func (r *opReader) time() CustomTime {
+	return CustomTime(time.Unix(int64(r.uint32()), 0).UTC())
+}
+
+// Reads an asset in either binary form: the HF26 one with a uint32 nai, or
+// the legacy one with a precision byte and a 7 byte symbol such as STEEM,
+// which dhive, hive-js, beem and cli_wallet still produce
+func (r *opReader) asset() Asset {
+	amount := r.int64()
+	if r.err == nil && r.remaining() >= 8 {
+		if symbol, ok := legacyAssetSymbol(r.b[r.pos : r.pos+8]); ok {
+			r.pos += 8
+			return Asset{amount, assets[symbol].precision, symbol}
+		}
+	}
+	pos := r.pos
+	nai := r.uint32()
+	if r.err != nil {
+		return Asset{}
+	}
+
+	for _, symbol := range naiSymbols(r.testnet) {
+		info := assets[symbol]
+		if nai == info.naiNum<<5|uint32(info.precision) {
+			r.naiAssets = append(r.naiAssets, naiAssetOffset{pos, symbol})
+			return Asset{amount, info.precision, symbol}
+		}
+	}
+	r.fail(fmt.Errorf("unknown asset nai %d", nai))
+	return Asset{}
+}
+
+// Returns the symbol of a legacy serialized asset symbol. No nai starts with
+// a precision byte followed by letters, so the two forms can't be confused.
+func legacyAssetSymbol(b []byte) (string, bool) {
+	for symbol := range assets {
+		if bytes.Equal(b, legacyAssetSymbolB(symbol)) {
+			return symbol, true
+		}
+	}
+	return "", false
+}
+
+// Returns the legacy binary form of an asset symbol: the precision and the
+// symbol padded to 7 bytes
+func legacyAssetSymbolB(symbol string) []byte {
+	info := assets[symbol]
+	b := make([]byte, 8)
+	b[0] = info.precision
+	copy(b[1:], info.legacySymbol)
+	return b
+}
+
+func (r *opReader) price() Price {
+	return Price{r.asset(), r.asset()}
+}
+
+func (r *opReader) publicKey() string {
+	b := r.next(33)
+	if r.err != nil {
+		return ""
+	}
+	if bytes.Equal(b, make([]byte, 33)) {
+		return NullPublicKey
+	}
+	pk, err := secp256k1.ParsePubKey(b)
+	if err != nil {
+		r.fail(err)
+		return ""
+	}
+	return *GetPublicKeyString(pk)
+}
+
+func (r *opReader) authority() Authority {
+	auth := Authority{Threshold: r.uint32()}
+	n := r.length()
+	auth.AccountAuths = make([]AccountAuth, 0, n)
+	for i := 0; i < n && r.err == nil; i++ {
+		auth.AccountAuths = append(auth.AccountAuths, AccountAuth{r.vstring(), r.uint16()})
+	}
+	n = r.length()
+	auth.KeyAuths = make([]KeyAuth, 0, n)
+	for i := 0; i < n && r.err == nil; i++ {
+		auth.KeyAuths = append(auth.KeyAuths, KeyAuth{r.publicKey(), r.uint16()})
+	}
+	return auth
+}
+
+func (r *opReader) optionalAuthority() *Authority {
+	if !r.bool() {
+		return nil
+	}
+	auth := r.authority()
+	return &auth
+}
+
+func (r *opReader) proposalIds() []int64 {
+	n := r.length()
+	ids := make([]int64, 0, n)
+	for i := 0; i < n && r.err == nil; i++ {
+		ids = append(ids, r.int64())
+	}
+	return ids
+}
+
+// reads extensions, decoding each one with the decoder registered for its
+// id. Operations without decoders only accept an empty list.
+func (r *opReader) extensions(decoders map[uint64]func(*opReader) HiveExtension) Extensions {
+	n := r.length()
+	exts := make(Extensions, 0, n)
+	for i := 0; i < n && r.err == nil; i++ {
+		id := r.uvarint()
+		decode, ok := decoders[id]
+		if !ok {
+			r.fail(fmt.Errorf("unsupported extension id %d", id))
+			return exts
+		}
+		exts = append(exts, decode(r))
+	}
+	return exts
+}
+
+// Decoders of the operations hivego can serialize, by operation name
+var opDecoders = map[string]func(*opReader) HiveOperation{
+	"vote": func(r *opReader) HiveOperation {
+		return VoteOperation{r.vstring(), r.vstring(), r.vstring(), int16(r.uint16()), "vote"}
+	},
+	"comment": func(r *opReader) HiveOperation {
+		return CommentOperation{r.vstring(), r.vstring(), r.vstring(), r.vstring(), r.vstring(), r.vstring(), r.vstring()}
+	},
+	"transfer": func(r *opReader) HiveOperation {
+		return TransferOperation{r.vstring(), r.vstring(), r.asset(), r.vstring()}
+	},
+	"transfer_to_vesting": func(r *opReader) HiveOperation {
+		return TransferToVestingOperation{r.vstring(), r.vstring(), r.asset()}
+	},
+	"withdraw_vesting": func(r *opReader) HiveOperation {
+		return WithdrawVestingOperation{r.vstring(), r.asset()}
+	},
+	"limit_order_create": func(r *opReader) HiveOperation {
+		return LimitOrderCreateOperation{r.vstring(), r.uint32(), r.asset(), r.asset(), r.bool(), r.time()}
+	},
+	"limit_order_cancel": func(r *opReader) HiveOperation {
+		return LimitOrderCancelOperation{r.vstring(), r.uint32()}
+	},
+	"feed_publish": func(r *opReader) HiveOperation {
+		return FeedPublishOperation{r.vstring(), r.price()}
+	},
+	"convert": func(r *opReader) HiveOperation {
+		return ConvertOperation{r.vstring(), r.uint32(), r.asset()}
+	},
+	"account_create": func(r *opReader) HiveOperation {
+		return AccountCreateOperation{
+			Fee:            r.asset(),
+			Creator:        r.vstring(),
+			NewAccountName: r.vstring(),
+			Owner:          r.authority(),
+			Active:         r.authority(),
+			Posting:        r.authority(),
+			MemoKey:        r.publicKey(),
+			JsonMetadata:   r.vstring(),
+		}
+	},
+	"account_update": func(r *opReader) HiveOperation {
+		return AccountUpdateOperation{
+			Account:      r.vstring(),
+			Owner:        r.optionalAuthority(),
+			Active:       r.optionalAuthority(),
+			Posting:      r.optionalAuthority(),
+			MemoKey:      r.publicKey(),
+			JsonMetadata: r.vstring(),
+			opText:       "account_update",
+		}
+	},
+	"witness_update": func(r *opReader) HiveOperation {
+		return WitnessUpdateOperation{
+			Owner:           r.vstring(),
+			Url:             r.vstring(),
+			BlockSigningKey: r.publicKey(),
+			Props:           ChainProperties{r.asset(), r.uint32(), r.uint16()},
+			Fee:             r.asset(),
+		}
+	},
+	"account_witness_vote": func(r *opReader) HiveOperation {
+		return AccountWitnessVoteOperation{r.vstring(), r.vstring(), r.bool()}
+	},
+	"account_witness_proxy": func(r *opReader) HiveOperation {
+		return AccountWitnessProxyOperation{r.vstring(), r.vstring()}
+	},
+	"custom": func(r *opReader) HiveOperation {
+		return CustomOperation{r.vstringArray(), r.uint16(), r.vbytes()}
+	},
+	"delete_comment": func(r *opReader) HiveOperation {
+		return DeleteCommentOperation{r.vstring(), r.vstring()}
+	},
+	"custom_json": func(r *opReader) HiveOperation {
+		return CustomJsonOperation{r.vstringArray(), r.vstringArray(), r.vstring(), r.vstring(), "custom_json"}
+	},
+	"comment_options": func(r *opReader) HiveOperation {
+		return CommentOptionsOperation{
+			Author:               r.vstring(),
+			Permlink:             r.vstring(),
+			MaxAcceptedPayout:    r.asset(),
+			PercentHbd:           r.uint16(),
+			AllowVotes:           r.bool(),
+			AllowCurationRewards: r.bool(),
+			Extensions: r.extensions(map[uint64]func(*opReader) HiveExtension{
+				0: func(r *opReader) HiveExtension {
+					n := r.length()
+					beneficiaries := make([]Beneficiary, 0, n)
+					for i := 0; i < n && r.err == nil; i++ {
+						beneficiaries = append(beneficiaries, Beneficiary{r.vstring(), r.uint16()})
+					}
+					return CommentPayoutBeneficiaries{beneficiaries}
+				},
+			}),
+		}
+	},
+	"set_withdraw_vesting_route": func(r *opReader) HiveOperation {
+		return SetWithdrawVestingRouteOperation{r.vstring(), r.vstring(), r.uint16(), r.bool()}
+	},
+	"limit_order_create2": func(r *opReader) HiveOperation {
+		return LimitOrderCreate2Operation{r.vstring(), r.uint32(), r.asset(), r.price(), r.bool(), r.time()}
+	},
+	"claim_account": func(r *opReader) HiveOperation {
+		return ClaimAccountOperation{r.vstring(), r.asset(), r.extensions(nil)}
+	},
+	"create_claimed_account": func(r *opReader) HiveOperation {
+		return CreateClaimedAccountOperation{
+			Creator:        r.vstring(),
+			NewAccountName: r.vstring(),
+			Owner:          r.authority(),
+			Active:         r.authority(),
+			Posting:        r.authority(),
+			MemoKey:        r.publicKey(),
+			JsonMetadata:   r.vstring(),
+			Extensions:     r.extensions(nil),
+		}
+	},
+	"request_account_recovery": func(r *opReader) HiveOperation {
+		return RequestAccountRecoveryOperation{r.vstring(), r.vstring(), r.authority(), r.extensions(nil)}
+	},
+	"recover_account": func(r *opReader) HiveOperation {
+		return RecoverAccountOperation{r.vstring(), r.authority(), r.authority(), r.extensions(nil)}
+	},
+	"change_recovery_account": func(r *opReader) HiveOperation {
+		return ChangeRecoveryAccountOperation{r.vstring(), r.vstring(), r.extensions(nil)}
+	},
+	"escrow_transfer": func(r *opReader) HiveOperation {
+		return EscrowTransferOperation{
+			From:                 r.vstring(),
+			To:                   r.vstring(),
+			HbdAmount:            r.asset(),
+			HiveAmount:           r.asset(),
+			EscrowId:             r.uint32(),
+			Agent:                r.vstring(),
+			Fee:                  r.asset(),
+			JsonMeta:             r.vstring(),
+			RatificationDeadline: r.time(),
+			EscrowExpiration:     r.time(),
+		}
+	},
+	"escrow_dispute": func(r *opReader) HiveOperation {
+		return EscrowDisputeOperation{r.vstring(), r.vstring(), r.vstring(), r.vstring(), r.uint32()}
+	},
+	"escrow_release": func(r *opReader) HiveOperation {
+		return EscrowReleaseOperation{r.vstring(), r.vstring(), r.vstring(), r.vstring(), r.vstring(), r.uint32(), r.asset(), r.asset()}
+	},
+	"escrow_approve": func(r *opReader) HiveOperation {
+		return EscrowApproveOperation{r.vstring(), r.vstring(), r.vstring(), r.vstring(), r.uint32(), r.bool()}
+	},
+	"transfer_to_savings": func(r *opReader) HiveOperation {
+		op := TransferToSavings{From: r.vstring(), To: r.vstring()}
+		op.Amount, op.Memo = r.asset(), r.vstring()
+		return op
+	},
+	"transfer_from_savings": func(r *opReader) HiveOperation {
+		op := TransferFromSavings{From: r.vstring(), RequestId: int(r.uint32())}
+		op.To, op.Amount, op.Memo = r.vstring(), r.asset(), r.vstring()
+		return op
+	},
+	"cancel_transfer_from_savings": func(r *opReader) HiveOperation {
+		return CancelTransferFromSavings{r.vstring(), int(r.uint32())}
+	},
+	"custom_binary": func(r *opReader) HiveOperation {
+		op := CustomBinaryOperation{
+			RequiredOwnerAuths:   r.vstringArray(),
+			RequiredActiveAuths:  r.vstringArray(),
+			RequiredPostingAuths: r.vstringArray(),
+		}
+		n := r.length()
+		op.RequiredAuths = make([]Authority, 0, n)
+		for i := 0; i < n && r.err == nil; i++ {
+			op.RequiredAuths = append(op.RequiredAuths, r.authority())
+		}
+		op.Id, op.Data = r.vstring(), r.vbytes()
+		return op
+	},
+	"claim_reward_balance": func(r *opReader) HiveOperation {
+		op := ClaimRewardOperation{Account: r.vstring(), opText: "claim_reward_balance"}
+		op.RewardHIVE, op.RewardHBD, op.RewardVests = r.asset(), r.asset(), r.asset()
+		return op
+	},
+	"delegate_vesting_shares": func(r *opReader) HiveOperation {
+		return DelegateVestingSharesOperation{r.vstring(), r.vstring(), r.asset()}
+	},
+	"account_create_with_delegation": func(r *opReader) HiveOperation {
+		return AccountCreateWithDelegationOperation{
+			Fee:            r.asset(),
+			Delegation:     r.asset(),
+			Creator:        r.vstring(),
+			NewAccountName: r.vstring(),
+			Owner:          r.authority(),
+			Active:         r.authority(),
+			Posting:        r.authority(),
+			MemoKey:        r.publicKey(),
+			JsonMetadata:   r.vstring(),
+			Extensions:     r.extensions(nil),
+		}
+	},
+	"witness_set_properties": func(r *opReader) HiveOperation {
+		op := WitnessSetPropertiesOperation{Owner: r.vstring()}
+		n := r.length()
+		props := make(map[string][]byte, n)
+		for i := 0; i < n && r.err == nil; i++ {
+			name := r.vstring()
+			props[name] = r.vbytes()
+		}
+		op.Props = WitnessProps{props: props}
+		op.Extensions = r.extensions(nil)
+		return op
+	},
+	"account_update2": func(r *opReader) HiveOperation {
+		op := AccountUpdate2Operation{
+			Account: r.vstring(),
+			Owner:   r.optionalAuthority(),
+			Active:  r.optionalAuthority(),
+			Posting: r.optionalAuthority(),
+		}
+		if r.bool() {
+			op.MemoKey = r.publicKey()
+		}
+		op.JsonMetadata, op.PostingJsonMetadata = r.vstring(), r.vstring()
+		op.Extensions = r.extensions(nil)
+		return op
+	},
+	"create_proposal": func(r *opReader) HiveOperation {
+		return CreateProposalOperation{
+			Creator:    r.vstring(),
+			Receiver:   r.vstring(),
+			StartDate:  r.time(),
+			EndDate:    r.time(),
+			DailyPay:   r.asset(),
+			Subject:    r.vstring(),
+			Permlink:   r.vstring(),
+			Extensions: r.extensions(nil),
+		}
+	},
+	"update_proposal_votes": func(r *opReader) HiveOperation {
+		return UpdateProposalVotesOperation{r.vstring(), r.proposalIds(), r.bool(), r.extensions(nil)}
+	},
+	"remove_proposal": func(r *opReader) HiveOperation {
+		return RemoveProposalOperation{r.vstring(), r.proposalIds(), r.extensions(nil)}
+	},
+	"update_proposal": func(r *opReader) HiveOperation {
+		return UpdateProposalOperation{
+			ProposalId: r.int64(),
+			Creator:    r.vstring(),
+			DailyPay:   r.asset(),
+			Subject:    r.vstring(),
+			Permlink:   r.vstring(),
+			Extensions: r.extensions(map[uint64]func(*opReader) HiveExtension{
+				1: func(r *opReader) HiveExtension {
+					return UpdateProposalEndDate{r.time()}
+				},
+			}),
+		}
+	},
+	"collateralized_convert": func(r *opReader) HiveOperation {
+		return CollateralizedConvertOperation{r.vstring(), r.uint32(), r.asset()}
+	},
+	"recurrent_transfer": func(r *opReader) HiveOperation {
+		return RecurrentTransferOperation{
+			From:       r.vstring(),
+			To:         r.vstring(),
+			Amount:     r.asset(),
+			Memo:       r.vstring(),
+			Recurrence: r.uint16(),
+			Executions: r.uint16(),
+			Extensions: r.extensions(map[uint64]func(*opReader) HiveExtension{
+				0: func(r *opReader) HiveExtension {
+					return RecurrentTransferPairId{r.uint8()}
+				},
+			}),
+		}
+	},
+}
+
+// Returns the operation name of each operation id
+func getHiveOpNames() map[uint64]string {
+	names := make(map[uint64]string)
+	for name, id := range getHiveOpIds() {
+		names[id] = name[:len(name)-len("_operation")]
+	}
+	return names
+}
+
+func (r *opReader) operation() HiveOperation {
+	id := r.uvarint()
+	if r.err != nil {
+		return nil
+	}
+	name, ok := getHiveOpNames()[id]
+	if !ok {
+		r.fail(fmt.Errorf("unknown operation id %d", id))
+		return nil
+	}
+	decode, ok := opDecoders[name]
+	if !ok {
+		r.fail(fmt.Errorf("decoding %s operations is not supported", name))
+		return nil
+	}
+	op := decode(r)
+	if r.err != nil {
+		r.err = fmt.Errorf("decoding %s: %w", name, r.err)
+		return nil
+	}
+	return op
+}
+
+// Decodes a single serialized operation, as produced by SerializeOp. Assets
+// get the symbols of the chain of chainId (mainnet if omitted).
+func DeserializeOp(b []byte, chainId ...string) (HiveOperation, error) {
+	r := &opReader{b: b, testnet: isTestnet(firstChainId(chainId))}
+	op := r.operation()
+	if r.err != nil {
+		return nil, r.err
+	}
+	if r.remaining() > 0 {
+		return nil, fmt.Errorf("%d unexpected bytes after the operation", r.remaining())
+	}
+	return op, nil
+}
+
+// reads a transaction without its signatures
+func (r *opReader) transaction() HiveTransaction {
+	tx := HiveTransaction{
+		RefBlockNum:    r.uint16(),
+		RefBlockPrefix: r.uint32(),
+		Expiration:     time.Time(r.time()).Format(customTimeLayout),
+	}
+
+	n := r.length()
+	for i := 0; i < n && r.err == nil; i++ {
+		tx.Operations = append(tx.Operations, r.operation())
+	}
+	if r.uvarint() != 0 {
+		r.fail(errors.New("transaction extensions are not supported"))
+	}
+	return tx
+}
+
+// Returns the legacy binary serialization of a transaction serialized by
+// SerializeTx, which writes assets with their legacy symbol instead of a nai.
+// The chain accepts signatures over either form since HF26 and most other
+// tools still sign the legacy one. The legacy symbols differ on testnets.
+func legacySerialization(b []byte, chainId string) ([]byte, error) {
+	r := &opReader{b: b, testnet: isTestnet(chainId)}
+	r.transaction()
+	if r.err != nil {
+		return nil, r.err
+	}
+
+	var legacy bytes.Buffer
+	last := 0
+	for _, asset := range r.naiAssets {
+		legacy.Write(b[last:asset.pos])
+		legacy.Write(legacyAssetSymbolB(asset.symbol))
+		last = asset.pos + 4
+	}
+	legacy.Write(b[last:r.pos])
+	return legacy.Bytes(), nil
+}
+
+// Decodes a serialized transaction, as produced by SerializeTx. If the
+// signatures of a signed_transaction follow, they are decoded too. Assets get
+// the symbols of the chain of chainId (mainnet if omitted).
+func DeserializeTx(b []byte, chainId ...string) (HiveTransaction, error) {
+	r := &opReader{b: b, testnet: isTestnet(firstChainId(chainId))}
+	tx := r.transaction()
+	if r.err != nil {
+		return HiveTransaction{}, r.err
+	}
+
+	if r.remaining() == 0 {
+		return tx, nil
+	}
+	n := r.length()
+	for i := 0; i < n && r.err == nil; i++ {
+		tx.Signatures = append(tx.Signatures, hex.EncodeToString(r.next(65)))
+	}
+	if r.err != nil {
+		return HiveTransaction{}, r.err
+	}
+	if r.remaining() > 0 {
+		return HiveTransaction{}, fmt.Errorf("%d unexpected bytes after the transaction", r.remaining())
+	}
+	return tx, nil
+}
+
+func firstChainId(chainId []string) string {
+	if len(chainId) == 0 {
+		return ""
+	}
+	return chainId[0]
+}
@@ -0,0 +1,178 @@
+package hivego
+
+import (
+	"bytes"
+	"encoding/hex"
+	"reflect"
+	"testing"
+)
+
+func getTestDeserializeOps() []HiveOperation {
+	owner := NewKeyAuthority(testPubKey)
+	props := NewWitnessProps(testPubKey).MaximumBlockSize(65536)
+
+	return []HiveOperation{
+		getTestVoteOp(),
+		getTestCommentOp(),
+		getTestTransferOp(),
+		getTestCustomJsonOp(),
+		getTestAccountUpdateOp(),
+		TransferToVestingOperation{"xeroc", "bob", MustParseAsset("1.000 HIVE")},
+		WithdrawVestingOperation{"xeroc", MustParseAsset("10.000000 VESTS")},
+		LimitOrderCreateOperation{"xeroc", 7, MustParseAsset("1.000 HIVE"), MustParseAsset("0.300 HBD"), false, getTestExpiration()},
+		LimitOrderCreate2Operation{"xeroc", 7, MustParseAsset("1.000 HIVE"), Price{MustParseAsset("1.000 HIVE"), MustParseAsset("0.300 HBD")}, true, getTestExpiration()},
+		LimitOrderCancelOperation{"xeroc", 7},
+		FeedPublishOperation{"xeroc", Price{MustParseAsset("0.300 HBD"), MustParseAsset("1.000 HIVE")}},
+		ConvertOperation{"xeroc", 3, MustParseAsset("1.000 HBD")},
+		CollateralizedConvertOperation{"xeroc", 4, MustParseAsset("1.000 HIVE")},
+		AccountCreateOperation{MustParseAsset("3.000 HIVE"), "xeroc", "bob", owner, owner, owner, testPubKey, "{}"},
+		AccountCreateWithDelegationOperation{MustParseAsset("3.000 HIVE"), MustParseAsset("1.000000 VESTS"), "xeroc", "bob", owner, owner, owner, testPubKey, "", Extensions{}},
+		ClaimAccountOperation{"xeroc", MustParseAsset("0.000 HIVE"), Extensions{}},
+		CreateClaimedAccountOperation{"xeroc", "bob", owner, owner, owner, testPubKey, "", Extensions{}},
+		RequestAccountRecoveryOperation{"xeroc", "bob", owner, Extensions{}},
+		RecoverAccountOperation{"bob", owner, owner, Extensions{}},
+		ChangeRecoveryAccountOperation{"bob", "xeroc", Extensions{}},
+		AccountUpdate2Operation{Account: "xeroc", Posting: &owner, PostingJsonMetadata: "{}", Extensions: Extensions{}},
+		AccountUpdate2Operation{Account: "xeroc", MemoKey: testPubKey, Extensions: Extensions{}},
+		WitnessUpdateOperation{"xeroc", "https://x", NullPublicKey, ChainProperties{MustParseAsset("3.000 HIVE"), 65536, 1000}, MustParseAsset("0.000 HIVE")},
+		WitnessSetPropertiesOperation{"xeroc", *props, Extensions{}},
+		AccountWitnessVoteOperation{"xeroc", "bob", true},
+		AccountWitnessProxyOperation{"xeroc", ""},
+		UpdateProposalVotesOperation{"xeroc", []int64{1, 5}, true, Extensions{}},
+		CreateProposalOperation{"xeroc", "bob", getTestExpiration(), getTestExpiration(), MustParseAsset("10.000 HBD"), "s", "p", Extensions{}},
+		UpdateProposalOperation{3, "xeroc", MustParseAsset("5.000 HBD"), "s", "p", Extensions{UpdateProposalEndDate{getTestExpiration()}}},
+		RemoveProposalOperation{"xeroc", []int64{2}, Extensions{}},
+		CommentOptionsOperation{"xeroc", "piston", MustParseAsset("1000000.000 HBD"), 10000, true, true,
+			Extensions{CommentPayoutBeneficiaries{[]Beneficiary{{"bob", 500}}}}},
+		DeleteCommentOperation{"xeroc", "piston"},
+		SetWithdrawVestingRouteOperation{"xeroc", "bob", 5000, true},
+		DelegateVestingSharesOperation{"xeroc", "bob", MustParseAsset("1.000000 VESTS")},
+		EscrowTransferOperation{"xeroc", "bob", MustParseAsset("1.000 HBD"), MustParseAsset("0.000 HIVE"), 1, "carol", MustParseAsset("0.001 HBD"), "", getTestExpiration(), getTestExpiration()},
+		EscrowApproveOperation{"xeroc", "bob", "carol", "bob", 1, true},
+		EscrowDisputeOperation{"xeroc", "bob", "carol", "bob", 1},
+		EscrowReleaseOperation{"xeroc", "bob", "carol", "xeroc", "bob", 1, MustParseAsset("1.000 HBD"), MustParseAsset("0.000 HIVE")},
+		TransferToSavings{Amount: MustParseAsset("1.000 HBD"), From: "xeroc", To: "bob", Memo: "m"},
+		TransferFromSavings{Amount: MustParseAsset("1.000 HBD"), From: "xeroc", To: "bob", Memo: "m", RequestId: 9},
+		CancelTransferFromSavings{"xeroc", 9},
+		ClaimRewardOperation{"xeroc", MustParseAsset("0.001 HBD"), MustParseAsset("0.002 HIVE"), MustParseAsset("0.000003 VESTS"), "claim_reward_balance"},
+		CustomOperation{[]string{"xeroc"}, 777, HexBytes{1, 2}},
+		CustomBinaryOperation{[]string{}, []string{}, []string{"xeroc"}, []Authority{owner}, "app", HexBytes{3}},
+		RecurrentTransferOperation{"xeroc", "bob", MustParseAsset("1.000 HBD"), "m", 24, 3, Extensions{RecurrentTransferPairId{2}}},
+	}
+}
+
+func TestDeserializeOpRoundTrip(t *testing.T) {
+	for _, op := range getTestDeserializeOps() {
+		b, err := op.SerializeOp()
+		if err != nil {
+			t.Fatal(op.OpName(), err)
+		}
+		decoded, err := DeserializeOp(b)
+		if err != nil {
+			t.Error(op.OpName(), err)
+			continue
+		}
+		if reflect.TypeOf(decoded) != reflect.TypeOf(op) {
+			t.Error("Expected", reflect.TypeOf(op), "got", reflect.TypeOf(decoded))
+			continue
+		}
+		got, err := decoded.SerializeOp()
+		if err != nil || !bytes.Equal(got, b) {
+			t.Error(op.OpName(), "does not round trip: expected", b, "got", got, err)
+		}
+	}
+}
+
+func TestDeserializeOpFields(t *testing.T) {
+	for _, op := range []HiveOperation{getTestVoteOp(), getTestTransferOp(), getTestCustomJsonOp()} {
+		b, _ := op.SerializeOp()
+		decoded, err := DeserializeOp(b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(decoded, op) {
+			t.Error("Expected", op, "got", decoded)
+		}
+	}
+}
+
+func TestDeserializeOpErrors(t *testing.T) {
+	b, _ := getTestTransferOp().SerializeOp()
+	inputs := [][]byte{
+		{},
+		b[:len(b)-1],
+		append(append([]byte{}, b...), 0),
+		{200},
+		// vote with a string length past the end of the data
+		{0, 100, 1},
+	}
+	for _, input := range inputs {
+		if _, err := DeserializeOp(input); err == nil {
+			t.Error("Expected an error decoding", input)
+		}
+	}
+}
+
+func TestDeserializeTx(t *testing.T) {
+	tx := getTestTx(getTwoTestOps())
+	b, err := SerializeTx(tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	decoded, err := DeserializeTx(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if decoded.RefBlockNum != tx.RefBlockNum || decoded.RefBlockPrefix != tx.RefBlockPrefix || decoded.Expiration != tx.Expiration {
+		t.Error("Expected", tx, "got", decoded)
+	}
+	if !reflect.DeepEqual(decoded.Operations, tx.Operations) {
+		t.Error("Expected", tx.Operations, "got", decoded.Operations)
+	}
+	if len(decoded.Signatures) != 0 {
+		t.Error("Expected no signatures, got", decoded.Signatures)
+	}
+
+	// signed_transaction: the signatures follow the transaction
+	sig := bytes.Repeat([]byte{0xab}, 65)
+	signed := append(append(append([]byte{}, b...), 1), sig...)
+	decoded, err = DeserializeTx(signed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(decoded.Signatures) != 1 || decoded.Signatures[0] != hex.EncodeToString(sig) {
+		t.Error("Unexpected signatures", decoded.Signatures)
+	}
+
+	if _, err := DeserializeTx(signed[:len(signed)-1]); err == nil {
+		t.Error("Expected an error for a truncated signature")
+	}
+}
+
+func TestDeserializeTxLegacyAssets(t *testing.T) {
+	// transfer serialized by python-steem, with assets in the legacy form
+	// (precision byte and "STEEM" symbol) rather than nais
+	b, _ := hex.DecodeString("f68585abf4dce7c80457010203666f6f046261617206b201000000000003535445454d000004466f6f6f00")
+	tx, err := DeserializeTx(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tx.RefBlockNum != 34294 || tx.RefBlockPrefix != 3707022213 || tx.Expiration != "2016-04-06T08:29:27" {
+		t.Error("Unexpected transaction", tx)
+	}
+	expected := []HiveOperation{TransferOperation{From: "foo", To: "baar", Amount: MustParseAsset("111.110 HIVE"), Memo: "Fooo"}}
+	if !reflect.DeepEqual(tx.Operations, expected) {
+		t.Error("Expected", expected, "got", tx.Operations)
+	}
+
+	// withdraw_vesting of 1.000000 VESTS by xeroc in the legacy form
+	b, _ = hex.DecodeString("04057865726f6340420f00000000000656455354530000")
+	op, err := DeserializeOp(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(op, WithdrawVestingOperation{"xeroc", MustParseAsset("1.000000 VESTS")}) {
+		t.Error("Unexpected operation", op)
+	}
+}
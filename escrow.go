@@ -0,0 +1,285 @@
+package hivego
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+type EscrowRole string
+
+const (
+	EscrowRoleFrom  EscrowRole = "from"
+	EscrowRoleTo    EscrowRole = "to"
+	EscrowRoleAgent EscrowRole = "agent"
+)
+
+type EscrowStep string
+
+const (
+	EscrowStepApprove EscrowStep = "approve"
+	EscrowStepDispute EscrowStep = "dispute"
+	EscrowStepRelease EscrowStep = "release"
+)
+
+// State of an escrow, as returned by condenser_api.get_escrow. The methods
+// build the operation for the next step of the escrow after checking that
+// the chain would accept it from the given account.
+type Escrow struct {
+	EscrowId             uint32     `json:"escrow_id"`
+	From                 string     `json:"from"`
+	To                   string     `json:"to"`
+	Agent                string     `json:"agent"`
+	RatificationDeadline CustomTime `json:"ratification_deadline"`
+	EscrowExpiration     CustomTime `json:"escrow_expiration"`
+	HbdBalance           Asset      `json:"hbd_balance"`
+	HiveBalance          Asset      `json:"hive_balance"`
+	PendingFee           Asset      `json:"pending_fee"`
+	ToApproved           bool       `json:"to_approved"`
+	AgentApproved        bool       `json:"agent_approved"`
+	Disputed             bool       `json:"disputed"`
+
+	// set once the escrow was rejected or fully released, it no longer exists on chain
+	Closed bool `json:"-"`
+}
+
+// Returns the escrow created by an escrow_transfer operation
+func NewEscrow(transfer EscrowTransferOperation) *Escrow {
+	return &Escrow{
+		EscrowId:             transfer.EscrowId,
+		From:                 transfer.From,
+		To:                   transfer.To,
+		Agent:                transfer.Agent,
+		RatificationDeadline: transfer.RatificationDeadline,
+		EscrowExpiration:     transfer.EscrowExpiration,
+		HbdBalance:           transfer.HbdAmount,
+		HiveBalance:          transfer.HiveAmount,
+		PendingFee:           transfer.Fee,
+	}
+}
+
+// Returns the role of account in the escrow
+func (e *Escrow) Role(account string) (EscrowRole, bool) {
+	switch account {
+	case e.From:
+		return EscrowRoleFrom, true
+	case e.To:
+		return EscrowRoleTo, true
+	case e.Agent:
+		return EscrowRoleAgent, true
+	}
+	return "", false
+}
+
+func (e *Escrow) ratified() bool {
+	return e.ToApproved && e.AgentApproved
+}
+
+// Returns the roles that may take step at time now
+func (e *Escrow) AllowedRoles(step EscrowStep, now time.Time) []EscrowRole {
+	if e.Closed {
+		return nil
+	}
+
+	var roles []EscrowRole
+	switch step {
+	case EscrowStepApprove:
+		if e.ratified() || now.After(e.RatificationDeadline.ToTime()) {
+			return nil
+		}
+		if !e.ToApproved {
+			roles = append(roles, EscrowRoleTo)
+		}
+		if !e.AgentApproved {
+			roles = append(roles, EscrowRoleAgent)
+		}
+	case EscrowStepDispute:
+		if e.ratified() && !e.Disputed && now.Before(e.EscrowExpiration.ToTime()) {
+			roles = append(roles, EscrowRoleFrom, EscrowRoleTo)
+		}
+	case EscrowStepRelease:
+		if !e.ratified() {
+			return nil
+		}
+		if e.Disputed {
+			roles = append(roles, EscrowRoleAgent)
+		} else {
+			roles = append(roles, EscrowRoleFrom, EscrowRoleTo)
+		}
+	}
+	return roles
+}
+
+func (e *Escrow) checkAllowed(step EscrowStep, who string, now time.Time) (EscrowRole, error) {
+	role, ok := e.Role(who)
+	if !ok {
+		return "", fmt.Errorf("%s is not part of escrow %d", who, e.EscrowId)
+	}
+	for _, allowed := range e.AllowedRoles(step, now) {
+		if allowed == role {
+			return role, nil
+		}
+	}
+	return "", fmt.Errorf("%s (%s) may not %s escrow %d now", who, role, step, e.EscrowId)
+}
+
+// Builds the approval (or rejection) of the escrow by the receiver or the agent
+func (e *Escrow) Approve(who string, approve bool, now time.Time) (EscrowApproveOperation, error) {
+	if _, err := e.checkAllowed(EscrowStepApprove, who, now); err != nil {
+		return EscrowApproveOperation{}, err
+	}
+	return EscrowApproveOperation{e.From, e.To, e.Agent, who, e.EscrowId, approve}, nil
+}
+
+// Builds a dispute of the escrow by the sender or the receiver, which hands
+// the release of the funds over to the agent
+func (e *Escrow) Dispute(who string, now time.Time) (EscrowDisputeOperation, error) {
+	if _, err := e.checkAllowed(EscrowStepDispute, who, now); err != nil {
+		return EscrowDisputeOperation{}, err
+	}
+	return EscrowDisputeOperation{e.From, e.To, e.Agent, who, e.EscrowId}, nil
+}
+
+// Builds a release of funds to receiver. Before expiration the sender may
+// only release to the receiver and the receiver only back to the sender.
+func (e *Escrow) Release(who string, receiver string, hbdAmount Asset, hiveAmount Asset, now time.Time) (EscrowReleaseOperation, error) {
+	role, err := e.checkAllowed(EscrowStepRelease, who, now)
+	if err != nil {
+		return EscrowReleaseOperation{}, err
+	}
+	if receiver != e.From && receiver != e.To {
+		return EscrowReleaseOperation{}, errors.New("funds can only be released to the sender or the receiver")
+	}
+	if !e.Disputed && now.Before(e.EscrowExpiration.ToTime()) {
+		if role == EscrowRoleFrom && receiver != e.To {
+			return EscrowReleaseOperation{}, errors.New("before expiration the sender may only release funds to the receiver")
+		}
+		if role == EscrowRoleTo && receiver != e.From {
+			return EscrowReleaseOperation{}, errors.New("before expiration the receiver may only release funds to the sender")
+		}
+	}
+	if _, err := subtractEscrowBalance(e.HbdBalance, hbdAmount); err != nil {
+		return EscrowReleaseOperation{}, err
+	}
+	if _, err := subtractEscrowBalance(e.HiveBalance, hiveAmount); err != nil {
+		return EscrowReleaseOperation{}, err
+	}
+
+	return EscrowReleaseOperation{e.From, e.To, e.Agent, who, receiver, e.EscrowId, hbdAmount, hiveAmount}, nil
+}
+
+// Updates the escrow with an operation that was included on chain
+func (e *Escrow) Apply(op HiveOperation) error {
+	switch o := op.(type) {
+	case EscrowApproveOperation:
+		if !o.Approve {
+			e.Closed = true
+			return nil
+		}
+		if o.Who == e.To {
+			e.ToApproved = true
+		}
+		if o.Who == e.Agent {
+			e.AgentApproved = true
+		}
+		// the agent is paid once both the receiver and the agent approved
+		if e.ToApproved && e.AgentApproved {
+			e.PendingFee.Amount = 0
+		}
+	case EscrowDisputeOperation:
+		e.Disputed = true
+	case EscrowReleaseOperation:
+		hbd, err := subtractEscrowBalance(e.HbdBalance, o.HbdAmount)
+		if err != nil {
+			return err
+		}
+		hive, err := subtractEscrowBalance(e.HiveBalance, o.HiveAmount)
+		if err != nil {
+			return err
+		}
+		e.HbdBalance, e.HiveBalance = hbd, hive
+		if hbd.IsZero() && hive.IsZero() {
+			e.Closed = true
+		}
+	default:
+		return fmt.Errorf("%s does not apply to an escrow", op.OpName())
+	}
+	return nil
+}
+
+// Broadcast an escrow_transfer and return the escrow it creates
+func (h *HiveRpcNode) EscrowTransfer(transfer EscrowTransferOperation, wif *string) (*Escrow, string, error) {
+	if !transfer.RatificationDeadline.ToTime().Before(transfer.EscrowExpiration.ToTime()) {
+		return nil, "", errors.New("ratification deadline must be before the escrow expiration")
+	}
+	txId, err := h.Broadcast([]HiveOperation{transfer}, wif)
+	if err != nil {
+		return nil, txId, err
+	}
+	return NewEscrow(transfer), txId, nil
+}
+
+func (h *HiveRpcNode) ApproveEscrow(e *Escrow, who string, approve bool, wif *string) (string, error) {
+	op, err := e.Approve(who, approve, time.Now())
+	if err != nil {
+		return "", err
+	}
+	return h.broadcastEscrowOp(e, op, wif)
+}
+
+func (h *HiveRpcNode) DisputeEscrow(e *Escrow, who string, wif *string) (string, error) {
+	op, err := e.Dispute(who, time.Now())
+	if err != nil {
+		return "", err
+	}
+	return h.broadcastEscrowOp(e, op, wif)
+}
+
+func (h *HiveRpcNode) ReleaseEscrow(e *Escrow, who string, receiver string, hbdAmount Asset, hiveAmount Asset, wif *string) (string, error) {
+	op, err := e.Release(who, receiver, hbdAmount, hiveAmount, time.Now())
+	if err != nil {
+		return "", err
+	}
+	return h.broadcastEscrowOp(e, op, wif)
+}
+
+func (h *HiveRpcNode) broadcastEscrowOp(e *Escrow, op HiveOperation, wif *string) (string, error) {
+	txId, err := h.Broadcast([]HiveOperation{op}, wif)
+	if err != nil {
+		return txId, err
+	}
+	return txId, e.Apply(op)
+}
+
+// Fetches the escrow escrowId of from. Returns nil if it does not exist
+// (anymore), which is the case once it was rejected or fully released.
+func (h *HiveRpcNode) GetEscrow(from string, escrowId uint32) (*Escrow, error) {
+	query := hrpcQuery{
+		method: "condenser_api.get_escrow",
+		params: []interface{}{from, escrowId},
+	}
+	res, err := h.rpcExec(query)
+	if err != nil {
+		return nil, err
+	}
+
+	var escrow *Escrow
+	err = json.Unmarshal(res, &escrow)
+	if err != nil {
+		return nil, err
+	}
+	return escrow, nil
+}
+
+func subtractEscrowBalance(balance Asset, amount Asset) (Asset, error) {
+	if err := amount.checkSymbol(balance.Symbol); err != nil {
+		return Asset{}, err
+	}
+	if cmp, err := amount.Cmp(balance); err != nil {
+		return Asset{}, err
+	} else if amount.Amount < 0 || cmp > 0 {
+		return Asset{}, fmt.Errorf("%s exceeds the escrow balance of %s", amount, balance)
+	}
+	return balance.Sub(amount)
+}
@@ -0,0 +1,111 @@
+package hivego
+
+import (
+	"testing"
+	"time"
+)
+
+func getTestEscrow() (*Escrow, time.Time) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	escrow := NewEscrow(EscrowTransferOperation{
+		From:                 "alice",
+		To:                   "bob",
+		Agent:                "eve",
+		EscrowId:             1,
+		HbdAmount:            MustParseAsset("10.000 HBD"),
+		HiveAmount:           MustParseAsset("0.000 HIVE"),
+		Fee:                  MustParseAsset("0.100 HBD"),
+		RatificationDeadline: CustomTime(now.Add(24 * time.Hour)),
+		EscrowExpiration:     CustomTime(now.Add(72 * time.Hour)),
+	})
+	return escrow, now
+}
+
+func TestEscrowWorkflow(t *testing.T) {
+	escrow, now := getTestEscrow()
+
+	if _, err := escrow.Approve("alice", true, now); err == nil {
+		t.Error("Expected the sender to be unable to approve")
+	}
+	if _, err := escrow.Release("alice", "bob", MustParseAsset("10.000 HBD"), MustParseAsset("0.000 HIVE"), now); err == nil {
+		t.Error("Expected release before ratification to fail")
+	}
+
+	for _, who := range []string{"bob", "eve"} {
+		op, err := escrow.Approve(who, true, now)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := escrow.Apply(op); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if escrow.PendingFee.String() != "0.000 HBD" {
+		t.Error("Expected the fee to be paid to the agent, got", escrow.PendingFee)
+	}
+
+	if _, err := escrow.Release("alice", "alice", MustParseAsset("1.000 HBD"), MustParseAsset("0.000 HIVE"), now); err == nil {
+		t.Error("Expected the sender to be unable to release to themselves before expiration")
+	}
+
+	op, err := escrow.Dispute("bob", now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	escrow.Apply(op)
+
+	roles := escrow.AllowedRoles(EscrowStepRelease, now)
+	if len(roles) != 1 || roles[0] != EscrowRoleAgent {
+		t.Error("Expected only the agent to release a disputed escrow, got", roles)
+	}
+
+	release, err := escrow.Release("eve", "alice", MustParseAsset("10.000 HBD"), MustParseAsset("0.000 HIVE"), now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := escrow.Apply(release); err != nil {
+		t.Fatal(err)
+	}
+	if !escrow.Closed {
+		t.Error("Expected the escrow to be closed after releasing all funds")
+	}
+}
+
+func TestEscrowAgentApprovesFirst(t *testing.T) {
+	escrow, now := getTestEscrow()
+
+	op, err := escrow.Approve("eve", true, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	escrow.Apply(op)
+	if escrow.PendingFee.String() != "0.100 HBD" {
+		t.Error("Expected the fee to stay pending until the receiver approves, got", escrow.PendingFee)
+	}
+
+	op, err = escrow.Approve("bob", true, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	escrow.Apply(op)
+	if escrow.PendingFee.String() != "0.000 HBD" {
+		t.Error("Expected the fee to be paid to the agent, got", escrow.PendingFee)
+	}
+}
+
+func TestEscrowReleaseAfterExpiration(t *testing.T) {
+	escrow, now := getTestEscrow()
+	escrow.ToApproved = true
+	escrow.AgentApproved = true
+
+	later := now.Add(96 * time.Hour)
+	if _, err := escrow.Release("alice", "alice", MustParseAsset("10.000 HBD"), MustParseAsset("0.000 HIVE"), later); err != nil {
+		t.Error("Expected the sender to be able to release to themselves after expiration:", err)
+	}
+	if _, err := escrow.Release("alice", "alice", MustParseAsset("11.000 HBD"), MustParseAsset("0.000 HIVE"), later); err == nil {
+		t.Error("Expected release above the balance to fail")
+	}
+	if _, err := escrow.Dispute("bob", later); err == nil {
+		t.Error("Expected dispute after expiration to fail")
+	}
+}
@@ -0,0 +1,169 @@
+package hivego
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Transaction in the HF26 JSON format accepted by network_broadcast_api:
+// operations as {"type": "transfer_operation", "value": {...}} objects and
+// assets as {"amount", "precision", "nai"} objects
+type hf26Transaction struct {
+	RefBlockNum    uint16        `json:"ref_block_num"`
+	RefBlockPrefix uint32        `json:"ref_block_prefix"`
+	Expiration     string        `json:"expiration"`
+	Operations     []hf26Variant `json:"operations"`
+	Extensions     []string      `json:"extensions"`
+	Signatures     []string      `json:"signatures"`
+}
+
+// static_variant (operation or extension) in the HF26 JSON format
+type hf26Variant struct {
+	Type  string      `json:"type"`
+	Value interface{} `json:"value"`
+}
+
+var (
+	assetType      = reflect.TypeOf(Asset{})
+	extensionsType = reflect.TypeOf(Extensions{})
+	marshalerType  = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+)
+
+// Returns the transaction in the HF26 JSON format
+func (t *HiveTransaction) MarshalHf26() ([]byte, error) {
+	tx, err := t.hf26()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(tx)
+}
+
+func (t *HiveTransaction) hf26() (hf26Transaction, error) {
+	tx := hf26Transaction{
+		RefBlockNum:    t.RefBlockNum,
+		RefBlockPrefix: t.RefBlockPrefix,
+		Expiration:     t.Expiration,
+		Operations:     make([]hf26Variant, 0, len(t.Operations)),
+		Extensions:     t.Extensions,
+		Signatures:     t.Signatures,
+	}
+	if tx.Extensions == nil {
+		tx.Extensions = []string{}
+	}
+	if tx.Signatures == nil {
+		tx.Signatures = []string{}
+	}
+	for _, op := range t.Operations {
+		hf26Op, err := hf26Operation(op)
+		if err != nil {
+			return hf26Transaction{}, err
+		}
+		tx.Operations = append(tx.Operations, hf26Op)
+	}
+	return tx, nil
+}
+
+// Returns the operation in the HF26 JSON format, which marshals to
+// {"type": "transfer_operation", "value": {...}}
+func hf26Operation(op HiveOperation) (hf26Variant, error) {
+	if generic, ok := op.(GenericOperation); ok {
+		return hf26Variant{generic.Type, generic.Value}, nil
+	}
+	value, err := hf26Value(reflect.ValueOf(op))
+	if err != nil {
+		return hf26Variant{}, fmt.Errorf("%s: %w", op.OpName(), err)
+	}
+	return hf26Variant{op.OpName() + "_operation", value}, nil
+}
+
+// Converts v to the value it has in HF26 JSON. Only assets and extensions
+// differ from the legacy format, everything else marshals the same.
+func hf26Value(v reflect.Value) (interface{}, error) {
+	switch v.Type() {
+	case assetType:
+		return NaiAsset(v.Interface().(Asset)), nil
+	case extensionsType:
+		return hf26Extensions(v.Interface().(Extensions))
+	}
+	if v.Type().Implements(marshalerType) {
+		return v.Interface(), nil
+	}
+
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if v.IsNil() {
+			return nil, nil
+		}
+		return hf26Value(v.Elem())
+	case reflect.Slice:
+		if v.IsNil() || v.Type().Elem().Kind() == reflect.Uint8 {
+			return v.Interface(), nil
+		}
+		items := make([]interface{}, v.Len())
+		for i := range items {
+			item, err := hf26Value(v.Index(i))
+			if err != nil {
+				return nil, err
+			}
+			items[i] = item
+		}
+		return items, nil
+	case reflect.Struct:
+		fields := make(map[string]interface{}, v.NumField())
+		for i := 0; i < v.NumField(); i++ {
+			field := v.Type().Field(i)
+			if field.PkgPath != "" {
+				continue
+			}
+			tag := strings.Split(field.Tag.Get("json"), ",")
+			name := tag[0]
+			if name == "-" {
+				continue
+			}
+			if name == "" {
+				name = field.Name
+			}
+			if len(tag) > 1 && tag[1] == "omitempty" && v.Field(i).IsZero() {
+				continue
+			}
+			value, err := hf26Value(v.Field(i))
+			if err != nil {
+				return nil, err
+			}
+			fields[name] = value
+		}
+		return fields, nil
+	}
+	return v.Interface(), nil
+}
+
+func hf26Extensions(exts Extensions) ([]hf26Variant, error) {
+	variants := make([]hf26Variant, 0, len(exts))
+	for _, ext := range exts {
+		var extType string
+		switch ext.(type) {
+		case CommentPayoutBeneficiaries:
+			extType = "comment_payout_beneficiaries"
+		case RecurrentTransferPairId:
+			extType = "recurrent_transfer_pair_id"
+		case UpdateProposalEndDate:
+			extType = "update_proposal_end_date"
+		default:
+			return nil, fmt.Errorf("extension %T has no HF26 JSON form", ext)
+		}
+
+		// the legacy form is [id, value], the value is the same in both formats
+		b, err := json.Marshal(ext)
+		if err != nil {
+			return nil, err
+		}
+		var tuple [2]json.RawMessage
+		if err := json.Unmarshal(b, &tuple); err != nil {
+			return nil, err
+		}
+		variants = append(variants, hf26Variant{extType, tuple[1]})
+	}
+	return variants, nil
+}
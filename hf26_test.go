@@ -0,0 +1,109 @@
+package hivego
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestMarshalHf26(t *testing.T) {
+	tx := HiveTransaction{
+		RefBlockNum:    36029,
+		RefBlockPrefix: 1164960351,
+		Expiration:     "2016-08-08T12:24:17",
+		Operations:     []HiveOperation{getTestTransferOp()},
+	}
+	got, err := tx.MarshalHf26()
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `{"ref_block_num":36029,"ref_block_prefix":1164960351,"expiration":"2016-08-08T12:24:17",` +
+		`"operations":[{"type":"transfer_operation","value":{"amount":{"amount":"1000","precision":3,"nai":"@@000000021"},"from":"tibfox.vsc","memo":"to=tibfox","to":"vsc.gateway"}}],` +
+		`"extensions":[],"signatures":[]}`
+	if string(got) != expected {
+		t.Error("Expected", expected, "got", string(got))
+	}
+}
+
+func TestHf26OperationExtensions(t *testing.T) {
+	op := NewCommentOptions("alice", "post")
+	op.SetBeneficiaries([]Beneficiary{{"bob", 1000}})
+	variant, err := hf26Operation(op)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := json.Marshal(variant)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `{"type":"comment_options_operation","value":{"allow_curation_rewards":true,"allow_votes":true,"author":"alice",` +
+		`"extensions":[{"type":"comment_payout_beneficiaries","value":{"beneficiaries":[{"account":"bob","weight":1000}]}}],` +
+		`"max_accepted_payout":{"amount":"1000000000","precision":3,"nai":"@@000000013"},"percent_hbd":10000,"permlink":"post"}}`
+	if string(got) != expected {
+		t.Error("Expected", expected, "got", string(got))
+	}
+
+	// the HF26 form decodes back into the same operation
+	var decoded Operation
+	if err := json.Unmarshal(got, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	back, err := decoded.Decode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	a, _ := op.SerializeOp()
+	b, _ := back.SerializeOp()
+	if string(a) != string(b) {
+		t.Error("Expected", op, "got", back)
+	}
+}
+
+func TestHf26OperationOmitEmpty(t *testing.T) {
+	variant, err := hf26Operation(AccountUpdate2Operation{Account: "alice", JsonMetadata: "{}", Extensions: Extensions{}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	value := variant.Value.(map[string]interface{})
+	for _, field := range []string{"owner", "active", "posting", "memo_key"} {
+		if _, ok := value[field]; ok {
+			t.Error("Expected", field, "to be left out")
+		}
+	}
+}
+
+func TestBroadcastQuery(t *testing.T) {
+	tx := getTestVoteTx()
+	h := NewHiveRpc([]string{"https://api.hive.blog"})
+
+	q, err := h.broadcastQuery(tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if q.method != "condenser_api.broadcast_transaction" {
+		t.Error("Expected condenser_api, got", q.method)
+	}
+
+	h.UseNetworkBroadcast = true
+	q, err = h.broadcastQuery(tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if q.method != "network_broadcast_api.broadcast_transaction" {
+		t.Error("Expected network_broadcast_api, got", q.method)
+	}
+	params, _ := json.Marshal(q.params)
+	var got struct {
+		Trx struct {
+			Operations []struct {
+				Type string `json:"type"`
+			} `json:"operations"`
+		} `json:"trx"`
+		MaxBlockAge int `json:"max_block_age"`
+	}
+	if err := json.Unmarshal(params, &got); err != nil {
+		t.Fatal(err)
+	}
+	if len(got.Trx.Operations) != 1 || got.Trx.Operations[0].Type != "vote_operation" || got.MaxBlockAge != -1 {
+		t.Error("Unexpected params", string(params))
+	}
+}
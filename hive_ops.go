@@ -2,7 +2,11 @@ package hivego
 
 import (
 	"encoding/hex"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"sort"
+	"time"
 )
 
 type HiveOperation interface {
@@ -10,7 +14,7 @@ type HiveOperation interface {
 	OpName() string
 }
 
-type voteOperation struct {
+type VoteOperation struct {
 	Voter    string `json:"voter"`
 	Author   string `json:"author"`
 	Permlink string `json:"permlink"`
@@ -18,52 +22,1018 @@ type voteOperation struct {
 	opText   string
 }
 
-func (o voteOperation) OpName() string {
+func (o VoteOperation) OpName() string {
 	return "vote"
 }
 
 func (h *HiveRpcNode) VotePost(voter string, author string, permlink string, weight int, wif *string) (string, error) {
-	vote := voteOperation{voter, author, permlink, int16(weight), "vote"}
+	vote := VoteOperation{voter, author, permlink, int16(weight), "vote"}
 
 	return h.Broadcast([]HiveOperation{vote}, wif)
 }
 
+type CommentOperation struct {
+	ParentAuthor   string `json:"parent_author"`
+	ParentPermlink string `json:"parent_permlink"`
+	Author         string `json:"author"`
+	Permlink       string `json:"permlink"`
+	Title          string `json:"title"`
+	Body           string `json:"body"`
+	JsonMetadata   string `json:"json_metadata"`
+}
+
+func (o CommentOperation) OpName() string {
+	return "comment"
+}
+
+// Builds a root post. The permlink is generated from the title and the first
+// tag of the metadata is used as the parent permlink (category or community).
+func NewPostOperation(author string, title string, body string, metadata PostMetadata) (CommentOperation, error) {
+	tags := metadata.normalizedTags()
+	if len(tags) == 0 {
+		return CommentOperation{}, errors.New("a root post needs at least one tag")
+	}
+	metadata.Tags = tags
+
+	jsonMetadata, err := metadata.String()
+	if err != nil {
+		return CommentOperation{}, err
+	}
+
+	return CommentOperation{
+		ParentAuthor:   "",
+		ParentPermlink: tags[0],
+		Author:         author,
+		Permlink:       GeneratePermlink(title),
+		Title:          title,
+		Body:           body,
+		JsonMetadata:   jsonMetadata,
+	}, nil
+}
+
+// Builds a reply to parentAuthor/parentPermlink with a generated permlink.
+func NewReplyOperation(author string, parentAuthor string, parentPermlink string, body string, metadata PostMetadata) (CommentOperation, error) {
+	metadata.Tags = metadata.normalizedTags()
+	jsonMetadata, err := metadata.String()
+	if err != nil {
+		return CommentOperation{}, err
+	}
+
+	return CommentOperation{
+		ParentAuthor:   parentAuthor,
+		ParentPermlink: parentPermlink,
+		Author:         author,
+		Permlink:       GenerateReplyPermlink(parentAuthor, parentPermlink),
+		Title:          "",
+		Body:           body,
+		JsonMetadata:   jsonMetadata,
+	}, nil
+}
+
+// Broadcast a root post, returns the transaction id and the generated permlink
+func (h *HiveRpcNode) Post(author string, title string, body string, metadata PostMetadata, wif *string) (string, string, error) {
+	op, err := NewPostOperation(author, title, body, metadata)
+	if err != nil {
+		return "", "", err
+	}
+
+	txId, err := h.Broadcast([]HiveOperation{op}, wif)
+	return txId, op.Permlink, err
+}
+
+// Broadcast a reply, returns the transaction id and the generated permlink
+func (h *HiveRpcNode) Reply(author string, parentAuthor string, parentPermlink string, body string, metadata PostMetadata, wif *string) (string, string, error) {
+	op, err := NewReplyOperation(author, parentAuthor, parentPermlink, body, metadata)
+	if err != nil {
+		return "", "", err
+	}
+
+	txId, err := h.Broadcast([]HiveOperation{op}, wif)
+	return txId, op.Permlink, err
+}
+
+type DeleteCommentOperation struct {
+	Author   string `json:"author"`
+	Permlink string `json:"permlink"`
+}
+
+func (o DeleteCommentOperation) OpName() string {
+	return "delete_comment"
+}
+
+func (h *HiveRpcNode) DeleteComment(author string, permlink string, wif *string) (string, error) {
+	op := DeleteCommentOperation{author, permlink}
+
+	return h.Broadcast([]HiveOperation{op}, wif)
+}
+
+// An entry of an operation's extensions. Hive encodes extensions as a
+// static_variant, so each entry carries the index of its variant type.
+type HiveExtension interface {
+	ExtensionId() uint64
+	SerializeExt() ([]byte, error)
+}
+
+type Extensions []HiveExtension
+
+// Always marshals to an array, the node rejects null extensions
+func (e Extensions) MarshalJSON() ([]byte, error) {
+	if e == nil {
+		return []byte("[]"), nil
+	}
+	return json.Marshal([]HiveExtension(e))
+}
+
+// Accepts both the legacy [id, value] and the {"type", "value"} forms. The
+// extension is recognized by its fields, as ids are only unique per operation.
+func (e *Extensions) UnmarshalJSON(b []byte) error {
+	var raw []json.RawMessage
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+
+	exts := make(Extensions, 0, len(raw))
+	for _, r := range raw {
+		var value map[string]json.RawMessage
+		var legacy []json.RawMessage
+		if err := json.Unmarshal(r, &legacy); err == nil {
+			if len(legacy) != 2 {
+				return fmt.Errorf("invalid extension %s", string(r))
+			}
+			if err := json.Unmarshal(legacy[1], &value); err != nil {
+				return err
+			}
+		} else {
+			var typed struct {
+				Value map[string]json.RawMessage `json:"value"`
+			}
+			if err := json.Unmarshal(r, &typed); err != nil {
+				return err
+			}
+			value = typed.Value
+		}
+
+		var ext HiveExtension
+		switch {
+		case value["beneficiaries"] != nil:
+			var beneficiaries CommentPayoutBeneficiaries
+			if err := json.Unmarshal(value["beneficiaries"], &beneficiaries.Beneficiaries); err != nil {
+				return err
+			}
+			ext = beneficiaries
+		case value["pair_id"] != nil:
+			var pairId RecurrentTransferPairId
+			if err := json.Unmarshal(value["pair_id"], &pairId.PairId); err != nil {
+				return err
+			}
+			ext = pairId
+		case value["end_date"] != nil:
+			var endDate UpdateProposalEndDate
+			if err := json.Unmarshal(value["end_date"], &endDate.EndDate); err != nil {
+				return err
+			}
+			ext = endDate
+		default:
+			return fmt.Errorf("unsupported extension %s", string(r))
+		}
+		exts = append(exts, ext)
+	}
+	*e = exts
+	return nil
+}
+
+type Beneficiary struct {
+	Account string `json:"account"`
+	Weight  uint16 `json:"weight"`
+}
+
+// comment_payout_beneficiaries extension of comment_options
+type CommentPayoutBeneficiaries struct {
+	Beneficiaries []Beneficiary `json:"beneficiaries"`
+}
+
+func (e CommentPayoutBeneficiaries) ExtensionId() uint64 {
+	return 0
+}
+
+// Returns the beneficiaries sorted by account as required by the chain
+func (e CommentPayoutBeneficiaries) sorted() ([]Beneficiary, error) {
+	if len(e.Beneficiaries) == 0 {
+		return nil, errors.New("at least one beneficiary is required")
+	}
+	if len(e.Beneficiaries) > 128 {
+		return nil, errors.New("at most 128 beneficiaries are allowed")
+	}
+
+	sorted := make([]Beneficiary, len(e.Beneficiaries))
+	copy(sorted, e.Beneficiaries)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Account < sorted[j].Account
+	})
+
+	sum := 0
+	for i, b := range sorted {
+		if i > 0 && sorted[i-1].Account == b.Account {
+			return nil, fmt.Errorf("duplicate beneficiary %s", b.Account)
+		}
+		sum += int(b.Weight)
+	}
+	if sum > 10000 {
+		return nil, fmt.Errorf("beneficiary weights sum to %d, must be at most 10000", sum)
+	}
+
+	return sorted, nil
+}
+
+func (e CommentPayoutBeneficiaries) MarshalJSON() ([]byte, error) {
+	sorted, err := e.sorted()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal([2]interface{}{e.ExtensionId(), struct {
+		Beneficiaries []Beneficiary `json:"beneficiaries"`
+	}{sorted}})
+}
+
+type CommentOptionsOperation struct {
+	Author               string     `json:"author"`
+	Permlink             string     `json:"permlink"`
+	MaxAcceptedPayout    Asset      `json:"max_accepted_payout"`
+	PercentHbd           uint16     `json:"percent_hbd"`
+	AllowVotes           bool       `json:"allow_votes"`
+	AllowCurationRewards bool       `json:"allow_curation_rewards"`
+	Extensions           Extensions `json:"extensions"`
+}
+
+func (o CommentOptionsOperation) OpName() string {
+	return "comment_options"
+}
+
+// Returns comment options with the chain defaults, to be adjusted by the caller
+func NewCommentOptions(author string, permlink string) CommentOptionsOperation {
+	return CommentOptionsOperation{
+		Author:               author,
+		Permlink:             permlink,
+		MaxAcceptedPayout:    MustParseAsset("1000000.000 HBD"),
+		PercentHbd:           10000,
+		AllowVotes:           true,
+		AllowCurationRewards: true,
+		Extensions:           Extensions{},
+	}
+}
+
+// Sets the beneficiaries of the comment, replacing any previously set. An
+// empty list removes the extension, which the chain requires to list at
+// least one beneficiary.
+func (o *CommentOptionsOperation) SetBeneficiaries(beneficiaries []Beneficiary) {
+	var exts Extensions
+	for _, ext := range o.Extensions {
+		if _, ok := ext.(CommentPayoutBeneficiaries); !ok {
+			exts = append(exts, ext)
+		}
+	}
+	if len(beneficiaries) > 0 {
+		exts = append(exts, CommentPayoutBeneficiaries{beneficiaries})
+	}
+	o.Extensions = exts
+}
+
+// Broadcast a comment and its options in the same transaction, so that the
+// payout settings are in place from the moment the comment exists.
+func (h *HiveRpcNode) CommentWithOptions(comment CommentOperation, options CommentOptionsOperation, wif *string) (string, error) {
+	if options.Author == "" && options.Permlink == "" {
+		options.Author = comment.Author
+		options.Permlink = comment.Permlink
+	}
+	if options.Author != comment.Author || options.Permlink != comment.Permlink {
+		return "", errors.New("comment options do not refer to the comment")
+	}
+
+	return h.Broadcast([]HiveOperation{comment, options}, wif)
+}
+
 type TransferFromSavings struct {
-	Amount    string `json:"amount"`
+	Amount    Asset  `json:"amount"`
 	From      string `json:"from"`
 	To        string `json:"to"`
 	Memo      string `json:"memo"`
 	RequestId int    `json:"request_id"`
 }
 
-func (o TransferFromSavings) OpName() string {
-	return "transfer_from_savings"
+func (o TransferFromSavings) OpName() string {
+	return "transfer_from_savings"
+}
+
+type TransferToSavings struct {
+	Amount Asset  `json:"amount"`
+	From   string `json:"from"`
+	To     string `json:"to"`
+	Memo   string `json:"memo"`
+}
+
+func (o TransferToSavings) OpName() string {
+	return "transfer_to_savings"
+}
+
+type CancelTransferFromSavings struct {
+	From      string `json:"from"`
+	RequestId int    `json:"request_id"`
+}
+
+func (o CancelTransferFromSavings) OpName() string {
+	return "cancel_transfer_from_savings"
+}
+
+type TransferToVestingOperation struct {
+	From   string `json:"from"`
+	To     string `json:"to"`
+	Amount Asset  `json:"amount"`
+}
+
+func (o TransferToVestingOperation) OpName() string {
+	return "transfer_to_vesting"
+}
+
+// Power up HIVE into HIVE Power. to may be another account or empty to power up from.
+func (h *HiveRpcNode) PowerUp(from string, to string, amount string, wif *string) (string, error) {
+	asset, err := parseAssetOf(amount, "HIVE", "TESTS")
+	if err != nil {
+		return "", err
+	}
+	if to == "" {
+		to = from
+	}
+	op := TransferToVestingOperation{from, to, asset}
+
+	return h.Broadcast([]HiveOperation{op}, wif)
+}
+
+type WithdrawVestingOperation struct {
+	Account       string `json:"account"`
+	VestingShares Asset  `json:"vesting_shares"`
+}
+
+func (o WithdrawVestingOperation) OpName() string {
+	return "withdraw_vesting"
+}
+
+// Start a power down of vestingShares (in VESTS), replacing any ongoing power down
+func (h *HiveRpcNode) PowerDown(account string, vestingShares string, wif *string) (string, error) {
+	vests, err := parseAssetOf(vestingShares, "VESTS")
+	if err != nil {
+		return "", err
+	}
+	op := WithdrawVestingOperation{account, vests}
+
+	return h.Broadcast([]HiveOperation{op}, wif)
+}
+
+// Stop an ongoing power down
+func (h *HiveRpcNode) CancelPowerDown(account string, wif *string) (string, error) {
+	op := WithdrawVestingOperation{account, MustParseAsset("0.000000 VESTS")}
+
+	return h.Broadcast([]HiveOperation{op}, wif)
+}
+
+type SetWithdrawVestingRouteOperation struct {
+	FromAccount string `json:"from_account"`
+	ToAccount   string `json:"to_account"`
+	Percent     uint16 `json:"percent"`
+	AutoVest    bool   `json:"auto_vest"`
+}
+
+func (o SetWithdrawVestingRouteOperation) OpName() string {
+	return "set_withdraw_vesting_route"
+}
+
+// Route percent (in basis points) of each power down payment to another
+// account, optionally as HIVE Power. A percent of 0 removes the route.
+func (h *HiveRpcNode) SetWithdrawRoute(from string, to string, percent uint16, autoVest bool, wif *string) (string, error) {
+	if percent > 10000 {
+		return "", errors.New("percent must be at most 10000")
+	}
+	op := SetWithdrawVestingRouteOperation{from, to, percent, autoVest}
+
+	return h.Broadcast([]HiveOperation{op}, wif)
+}
+
+type DelegateVestingSharesOperation struct {
+	Delegator     string `json:"delegator"`
+	Delegatee     string `json:"delegatee"`
+	VestingShares Asset  `json:"vesting_shares"`
+}
+
+func (o DelegateVestingSharesOperation) OpName() string {
+	return "delegate_vesting_shares"
+}
+
+// Delegate VESTS to another account. Delegating "0.000000 VESTS" removes the delegation.
+func (h *HiveRpcNode) DelegateVests(delegator string, delegatee string, vestingShares string, wif *string) (string, error) {
+	vests, err := parseAssetOf(vestingShares, "VESTS")
+	if err != nil {
+		return "", err
+	}
+	op := DelegateVestingSharesOperation{delegator, delegatee, vests}
+
+	return h.Broadcast([]HiveOperation{op}, wif)
+}
+
+// Delegate an amount of HIVE Power (e.g. "100.000 HIVE"), converted to VESTS
+// with freshly fetched global properties
+func (h *HiveRpcNode) DelegateHP(delegator string, delegatee string, hp string, wif *string) (string, error) {
+	hive, err := parseAssetOf(hp, "HIVE", "TESTS")
+	if err != nil {
+		return "", err
+	}
+	props, err := h.GetGlobalProps()
+	if err != nil {
+		return "", err
+	}
+	vests, err := ConvertHPToVests(hive, props)
+	if err != nil {
+		return "", err
+	}
+	op := DelegateVestingSharesOperation{delegator, delegatee, vests}
+
+	return h.Broadcast([]HiveOperation{op}, wif)
+}
+
+// Exchange rate between two assets, e.g. base "1.000 HBD" for quote "4.000 HIVE"
+type Price struct {
+	Base  Asset `json:"base"`
+	Quote Asset `json:"quote"`
+}
+
+type LimitOrderCreateOperation struct {
+	Owner        string     `json:"owner"`
+	OrderId      uint32     `json:"orderid"`
+	AmountToSell Asset      `json:"amount_to_sell"`
+	MinToReceive Asset      `json:"min_to_receive"`
+	FillOrKill   bool       `json:"fill_or_kill"`
+	Expiration   CustomTime `json:"expiration"`
+}
+
+func (o LimitOrderCreateOperation) OpName() string {
+	return "limit_order_create"
+}
+
+type LimitOrderCreate2Operation struct {
+	Owner        string     `json:"owner"`
+	OrderId      uint32     `json:"orderid"`
+	AmountToSell Asset      `json:"amount_to_sell"`
+	ExchangeRate Price      `json:"exchange_rate"`
+	FillOrKill   bool       `json:"fill_or_kill"`
+	Expiration   CustomTime `json:"expiration"`
+}
+
+func (o LimitOrderCreate2Operation) OpName() string {
+	return "limit_order_create2"
+}
+
+type LimitOrderCancelOperation struct {
+	Owner   string `json:"owner"`
+	OrderId uint32 `json:"orderid"`
+}
+
+func (o LimitOrderCancelOperation) OpName() string {
+	return "limit_order_cancel"
+}
+
+// Orders can't live longer than 28 days on chain
+const maxOrderExpiration = 28 * 24 * time.Hour
+
+// Place an order on the internal market selling amountToSell for at least
+// minToReceive. One side must be HIVE and the other HBD. A zero expiration
+// defaults to the longest expiration allowed by the chain.
+func (h *HiveRpcNode) PlaceLimitOrder(owner string, orderId uint32, amountToSell string, minToReceive string, expiration time.Time, fillOrKill bool, wif *string) (string, error) {
+	sell, err := ParseAsset(amountToSell)
+	if err != nil {
+		return "", err
+	}
+	receive, err := ParseAsset(minToReceive)
+	if err != nil {
+		return "", err
+	}
+	if err := checkMarketPair(sell, receive); err != nil {
+		return "", err
+	}
+	expires, err := orderExpiration(expiration)
+	if err != nil {
+		return "", err
+	}
+	op := LimitOrderCreateOperation{
+		Owner:        owner,
+		OrderId:      orderId,
+		AmountToSell: sell,
+		MinToReceive: receive,
+		FillOrKill:   fillOrKill,
+		Expiration:   expires,
+	}
+
+	return h.Broadcast([]HiveOperation{op}, wif)
+}
+
+// Place an order on the internal market selling amountToSell at the given
+// exchange rate, whose base must be in the asset being sold
+func (h *HiveRpcNode) PlaceLimitOrderAtPrice(owner string, orderId uint32, amountToSell string, exchangeRate Price, expiration time.Time, fillOrKill bool, wif *string) (string, error) {
+	if err := checkMarketPair(exchangeRate.Base, exchangeRate.Quote); err != nil {
+		return "", err
+	}
+	sell, err := ParseAsset(amountToSell)
+	if err != nil {
+		return "", err
+	}
+	if err := exchangeRate.Base.checkSymbol(sell.Symbol); err != nil {
+		return "", fmt.Errorf("exchange rate base must be in the asset being sold: %w", err)
+	}
+	expires, err := orderExpiration(expiration)
+	if err != nil {
+		return "", err
+	}
+	op := LimitOrderCreate2Operation{
+		Owner:        owner,
+		OrderId:      orderId,
+		AmountToSell: sell,
+		ExchangeRate: exchangeRate,
+		FillOrKill:   fillOrKill,
+		Expiration:   expires,
+	}
+
+	return h.Broadcast([]HiveOperation{op}, wif)
+}
+
+func (h *HiveRpcNode) CancelLimitOrder(owner string, orderId uint32, wif *string) (string, error) {
+	op := LimitOrderCancelOperation{owner, orderId}
+
+	return h.Broadcast([]HiveOperation{op}, wif)
+}
+
+func orderExpiration(expiration time.Time) (CustomTime, error) {
+	if expiration.IsZero() {
+		// leave some room for the difference between local and head block time
+		expiration = time.Now().Add(maxOrderExpiration - time.Hour)
+	}
+	if expiration.After(time.Now().Add(maxOrderExpiration)) {
+		return CustomTime{}, fmt.Errorf("orders can't expire more than 28 days ahead, got %s", expiration.UTC().Format(customTimeLayout))
+	}
+	return CustomTime(expiration.UTC().Truncate(time.Second)), nil
+}
+
+// Checks that one asset is HIVE and the other HBD
+func checkMarketPair(a Asset, b Asset) error {
+	pair := a.Symbol + "/" + b.Symbol
+	switch pair {
+	case "HIVE/HBD", "HBD/HIVE", "TESTS/TBD", "TBD/TESTS":
+		return nil
+	}
+	return fmt.Errorf("orders must trade HIVE against HBD, got %s", pair)
+}
+
+type ConvertOperation struct {
+	Owner     string `json:"owner"`
+	RequestId uint32 `json:"requestid"`
+	Amount    Asset  `json:"amount"`
+}
+
+func (o ConvertOperation) OpName() string {
+	return "convert"
+}
+
+// Convert HBD to HIVE at the median feed price after 3.5 days. The request id
+// is allocated with NextConversionRequestId.
+func (h *HiveRpcNode) ConvertHbd(owner string, amount string, wif *string) (string, error) {
+	hbd, err := parseAssetOf(amount, "HBD", "TBD")
+	if err != nil {
+		return "", err
+	}
+	requestId, err := h.NextConversionRequestId(owner)
+	if err != nil {
+		return "", err
+	}
+	op := ConvertOperation{owner, requestId, hbd}
+
+	return h.Broadcast([]HiveOperation{op}, wif)
+}
+
+type CollateralizedConvertOperation struct {
+	Owner     string `json:"owner"`
+	RequestId uint32 `json:"requestid"`
+	Amount    Asset  `json:"amount"`
+}
+
+func (o CollateralizedConvertOperation) OpName() string {
+	return "collateralized_convert"
+}
+
+// Convert HIVE to HBD immediately, using HIVE as collateral for 3.5 days.
+// The request id is allocated with NextConversionRequestId.
+func (h *HiveRpcNode) CollateralizedConvert(owner string, amount string, wif *string) (string, error) {
+	hive, err := parseAssetOf(amount, "HIVE", "TESTS")
+	if err != nil {
+		return "", err
+	}
+	requestId, err := h.NextConversionRequestId(owner)
+	if err != nil {
+		return "", err
+	}
+	op := CollateralizedConvertOperation{owner, requestId, hive}
+
+	return h.Broadcast([]HiveOperation{op}, wif)
+}
+
+type EscrowTransferOperation struct {
+	From                 string     `json:"from"`
+	To                   string     `json:"to"`
+	HbdAmount            Asset      `json:"hbd_amount"`
+	HiveAmount           Asset      `json:"hive_amount"`
+	EscrowId             uint32     `json:"escrow_id"`
+	Agent                string     `json:"agent"`
+	Fee                  Asset      `json:"fee"`
+	JsonMeta             string     `json:"json_meta"`
+	RatificationDeadline CustomTime `json:"ratification_deadline"`
+	EscrowExpiration     CustomTime `json:"escrow_expiration"`
+}
+
+func (o EscrowTransferOperation) OpName() string {
+	return "escrow_transfer"
+}
+
+type EscrowApproveOperation struct {
+	From     string `json:"from"`
+	To       string `json:"to"`
+	Agent    string `json:"agent"`
+	Who      string `json:"who"`
+	EscrowId uint32 `json:"escrow_id"`
+	Approve  bool   `json:"approve"`
+}
+
+func (o EscrowApproveOperation) OpName() string {
+	return "escrow_approve"
+}
+
+type EscrowDisputeOperation struct {
+	From     string `json:"from"`
+	To       string `json:"to"`
+	Agent    string `json:"agent"`
+	Who      string `json:"who"`
+	EscrowId uint32 `json:"escrow_id"`
+}
+
+func (o EscrowDisputeOperation) OpName() string {
+	return "escrow_dispute"
+}
+
+type EscrowReleaseOperation struct {
+	From       string `json:"from"`
+	To         string `json:"to"`
+	Agent      string `json:"agent"`
+	Who        string `json:"who"`
+	Receiver   string `json:"receiver"`
+	EscrowId   uint32 `json:"escrow_id"`
+	HbdAmount  Asset  `json:"hbd_amount"`
+	HiveAmount Asset  `json:"hive_amount"`
+}
+
+func (o EscrowReleaseOperation) OpName() string {
+	return "escrow_release"
+}
+
+// Legacy chain properties voted by witnesses through witness_update
+type ChainProperties struct {
+	AccountCreationFee Asset  `json:"account_creation_fee"`
+	MaximumBlockSize   uint32 `json:"maximum_block_size"`
+	HbdInterestRate    uint16 `json:"hbd_interest_rate"`
+}
+
+type WitnessUpdateOperation struct {
+	Owner           string          `json:"owner"`
+	Url             string          `json:"url"`
+	BlockSigningKey string          `json:"block_signing_key"`
+	Props           ChainProperties `json:"props"`
+	Fee             Asset           `json:"fee"`
+}
+
+func (o WitnessUpdateOperation) OpName() string {
+	return "witness_update"
+}
+
+// Register or update a witness. The fee is not used by the chain anymore and
+// is always 0.
+func (h *HiveRpcNode) WitnessUpdate(owner string, url string, blockSigningKey string, props ChainProperties, wif *string) (string, error) {
+	op := WitnessUpdateOperation{owner, url, blockSigningKey, props, MustParseAsset("0.000 HIVE")}
+
+	return h.Broadcast([]HiveOperation{op}, wif)
+}
+
+type WitnessSetPropertiesOperation struct {
+	Owner      string       `json:"owner"`
+	Props      WitnessProps `json:"props"`
+	Extensions Extensions   `json:"extensions"`
+}
+
+func (o WitnessSetPropertiesOperation) OpName() string {
+	return "witness_set_properties"
+}
+
+// Update witness properties, built with NewWitnessProps
+func (h *HiveRpcNode) WitnessSetProperties(owner string, props *WitnessProps, wif *string) (string, error) {
+	if err := props.Err(); err != nil {
+		return "", err
+	}
+	op := WitnessSetPropertiesOperation{owner, *props, Extensions{}}
+
+	return h.Broadcast([]HiveOperation{op}, wif)
+}
+
+// Stop producing blocks by setting the signing key to the null key
+func (h *HiveRpcNode) DisableWitness(owner string, currentSigningKey string, wif *string) (string, error) {
+	props := NewWitnessProps(currentSigningKey).NewSigningKey(NullPublicKey)
+
+	return h.WitnessSetProperties(owner, props, wif)
 }
 
-type TransferToSavings struct {
-	Amount string `json:"amount"`
-	From   string `json:"from"`
-	To     string `json:"to"`
-	Memo   string `json:"memo"`
+type FeedPublishOperation struct {
+	Publisher    string `json:"publisher"`
+	ExchangeRate Price  `json:"exchange_rate"`
 }
 
-func (o TransferToSavings) OpName() string {
-	return "transfer_to_savings"
+func (o FeedPublishOperation) OpName() string {
+	return "feed_publish"
 }
 
-type CancelTransferFromSavings struct {
-	From      string `json:"from"`
-	RequestId int    `json:"request_id"`
+// Publish a price feed, e.g. base "0.300 HBD" for quote "1.000 HIVE"
+func (h *HiveRpcNode) FeedPublish(publisher string, exchangeRate Price, wif *string) (string, error) {
+	if err := exchangeRate.Base.checkSymbol("HBD", "TBD"); err != nil {
+		return "", err
+	}
+	if err := exchangeRate.Quote.checkSymbol("HIVE", "TESTS"); err != nil {
+		return "", err
+	}
+	op := FeedPublishOperation{publisher, exchangeRate}
+
+	return h.Broadcast([]HiveOperation{op}, wif)
 }
 
-func (o CancelTransferFromSavings) OpName() string {
-	return "cancel_transfer_from_savings"
+type AccountWitnessVoteOperation struct {
+	Account string `json:"account"`
+	Witness string `json:"witness"`
+	Approve bool   `json:"approve"`
+}
+
+func (o AccountWitnessVoteOperation) OpName() string {
+	return "account_witness_vote"
+}
+
+// An account can vote for at most 30 witnesses
+const maxWitnessVotes = 30
+
+// Vote or unvote a witness. Fails before broadcasting if the account has set a
+// proxy, already is in the requested state or has no witness votes left.
+func (h *HiveRpcNode) VoteWitness(account string, witness string, approve bool, wif *string) (string, error) {
+	accountData, err := h.getSingleAccount(account)
+	if err != nil {
+		return "", err
+	}
+	if accountData.Proxy != "" {
+		return "", fmt.Errorf("%s has set %s as proxy, clear the proxy before voting", account, accountData.Proxy)
+	}
+
+	voted := false
+	for _, w := range accountData.WitnessVotes {
+		if w == witness {
+			voted = true
+			break
+		}
+	}
+	if approve && voted {
+		return "", fmt.Errorf("%s already votes for %s", account, witness)
+	}
+	if !approve && !voted {
+		return "", fmt.Errorf("%s does not vote for %s", account, witness)
+	}
+	if approve && len(accountData.WitnessVotes) >= maxWitnessVotes {
+		return "", fmt.Errorf("%s already votes for %d witnesses", account, maxWitnessVotes)
+	}
+
+	op := AccountWitnessVoteOperation{account, witness, approve}
+	return h.Broadcast([]HiveOperation{op}, wif)
+}
+
+type AccountWitnessProxyOperation struct {
+	Account string `json:"account"`
+	Proxy   string `json:"proxy"`
+}
+
+func (o AccountWitnessProxyOperation) OpName() string {
+	return "account_witness_proxy"
+}
+
+// Let proxy vote for witnesses and proposals on behalf of account
+func (h *HiveRpcNode) SetProxy(account string, proxy string, wif *string) (string, error) {
+	if proxy == "" || proxy == account {
+		return "", errors.New("proxy must be another account, use ClearProxy to remove it")
+	}
+	accountData, err := h.getSingleAccount(account)
+	if err != nil {
+		return "", err
+	}
+	if accountData.Proxy == proxy {
+		return "", fmt.Errorf("%s already has %s as proxy", account, proxy)
+	}
+
+	op := AccountWitnessProxyOperation{account, proxy}
+	return h.Broadcast([]HiveOperation{op}, wif)
+}
+
+// Remove the proxy of account so that it votes by itself again
+func (h *HiveRpcNode) ClearProxy(account string, wif *string) (string, error) {
+	accountData, err := h.getSingleAccount(account)
+	if err != nil {
+		return "", err
+	}
+	if accountData.Proxy == "" {
+		return "", fmt.Errorf("%s has no proxy", account)
+	}
+
+	op := AccountWitnessProxyOperation{account, ""}
+	return h.Broadcast([]HiveOperation{op}, wif)
 }
 
-type Auths struct {
-	WeightThreshold int              `json:"weight_threshold"`
-	AccountAuths    [][2]interface{} `json:"account_auths"` // tuple (string, int)
-	KeyAuths        [][2]interface{} `json:"key_auths"`     // tuple (string, int)
+type UpdateProposalVotesOperation struct {
+	Voter       string     `json:"voter"`
+	ProposalIds []int64    `json:"proposal_ids"`
+	Approve     bool       `json:"approve"`
+	Extensions  Extensions `json:"extensions"`
+}
+
+func (o UpdateProposalVotesOperation) OpName() string {
+	return "update_proposal_votes"
+}
+
+// A single update_proposal_votes can carry at most 5 proposals
+const maxProposalIds = 5
+
+// Vote or unvote DHF proposals. Fails before broadcasting if the voter has
+// set a proxy, which then votes for proposals as well.
+func (h *HiveRpcNode) VoteProposals(voter string, proposalIds []int64, approve bool, wif *string) (string, error) {
+	ids := sortedProposalIds(proposalIds)
+	if len(ids) == 0 || len(ids) > maxProposalIds {
+		return "", fmt.Errorf("between 1 and %d proposal ids are required", maxProposalIds)
+	}
+	accountData, err := h.getSingleAccount(voter)
+	if err != nil {
+		return "", err
+	}
+	if accountData.Proxy != "" {
+		return "", fmt.Errorf("%s has set %s as proxy, clear the proxy before voting", voter, accountData.Proxy)
+	}
+
+	op := UpdateProposalVotesOperation{voter, ids, approve, Extensions{}}
+	return h.Broadcast([]HiveOperation{op}, wif)
+}
+
+type CreateProposalOperation struct {
+	Creator    string     `json:"creator"`
+	Receiver   string     `json:"receiver"`
+	StartDate  CustomTime `json:"start_date"`
+	EndDate    CustomTime `json:"end_date"`
+	DailyPay   Asset      `json:"daily_pay"`
+	Subject    string     `json:"subject"`
+	Permlink   string     `json:"permlink"`
+	Extensions Extensions `json:"extensions"`
+}
+
+func (o CreateProposalOperation) OpName() string {
+	return "create_proposal"
+}
+
+// update_proposal_end_date extension of update_proposal
+type UpdateProposalEndDate struct {
+	EndDate CustomTime `json:"end_date"`
+}
+
+func (e UpdateProposalEndDate) ExtensionId() uint64 {
+	return 1
+}
+
+func (e UpdateProposalEndDate) MarshalJSON() ([]byte, error) {
+	return json.Marshal([2]interface{}{e.ExtensionId(), struct {
+		EndDate CustomTime `json:"end_date"`
+	}{e.EndDate}})
+}
+
+type UpdateProposalOperation struct {
+	ProposalId int64      `json:"proposal_id"`
+	Creator    string     `json:"creator"`
+	DailyPay   Asset      `json:"daily_pay"`
+	Subject    string     `json:"subject"`
+	Permlink   string     `json:"permlink"`
+	Extensions Extensions `json:"extensions"`
+}
+
+func (o UpdateProposalOperation) OpName() string {
+	return "update_proposal"
+}
+
+type RemoveProposalOperation struct {
+	ProposalOwner string     `json:"proposal_owner"`
+	ProposalIds   []int64    `json:"proposal_ids"`
+	Extensions    Extensions `json:"extensions"`
+}
+
+func (o RemoveProposalOperation) OpName() string {
+	return "remove_proposal"
+}
+
+// Parameters of a new DHF proposal. Permlink must point to an existing post
+// of the creator describing the proposal.
+type ProposalParams struct {
+	Creator   string
+	Receiver  string
+	StartDate time.Time
+	EndDate   time.Time
+	DailyPay  string
+	Subject   string
+	Permlink  string
+}
+
+func (h *HiveRpcNode) CreateProposal(params ProposalParams, wif *string) (string, error) {
+	dailyPay, err := parseAssetOf(params.DailyPay, "HBD", "TBD")
+	if err != nil {
+		return "", err
+	}
+	if !params.StartDate.Before(params.EndDate) {
+		return "", errors.New("proposal start date must be before its end date")
+	}
+	op := CreateProposalOperation{
+		Creator:    params.Creator,
+		Receiver:   params.Receiver,
+		StartDate:  CustomTime(params.StartDate.UTC().Truncate(time.Second)),
+		EndDate:    CustomTime(params.EndDate.UTC().Truncate(time.Second)),
+		DailyPay:   dailyPay,
+		Subject:    params.Subject,
+		Permlink:   params.Permlink,
+		Extensions: Extensions{},
+	}
+
+	return h.Broadcast([]HiveOperation{op}, wif)
+}
+
+// Changes to an existing proposal. The chain only allows lowering the daily
+// pay and moving the end date earlier. A zero EndDate keeps the end date.
+type ProposalUpdate struct {
+	Creator  string
+	DailyPay string
+	Subject  string
+	Permlink string
+	EndDate  time.Time
+}
+
+func (h *HiveRpcNode) UpdateProposal(proposalId int64, update ProposalUpdate, wif *string) (string, error) {
+	dailyPay, err := parseAssetOf(update.DailyPay, "HBD", "TBD")
+	if err != nil {
+		return "", err
+	}
+	op := UpdateProposalOperation{
+		ProposalId: proposalId,
+		Creator:    update.Creator,
+		DailyPay:   dailyPay,
+		Subject:    update.Subject,
+		Permlink:   update.Permlink,
+		Extensions: Extensions{},
+	}
+	if !update.EndDate.IsZero() {
+		op.Extensions = append(op.Extensions, UpdateProposalEndDate{CustomTime(update.EndDate.UTC().Truncate(time.Second))})
+	}
+
+	return h.Broadcast([]HiveOperation{op}, wif)
+}
+
+// Withdraw proposals of owner
+func (h *HiveRpcNode) RemoveProposals(owner string, proposalIds []int64, wif *string) (string, error) {
+	ids := sortedProposalIds(proposalIds)
+	if len(ids) == 0 || len(ids) > maxProposalIds {
+		return "", fmt.Errorf("between 1 and %d proposal ids are required", maxProposalIds)
+	}
+	op := RemoveProposalOperation{owner, ids, Extensions{}}
+
+	return h.Broadcast([]HiveOperation{op}, wif)
+}
+
+// Returns the ids sorted and without duplicates, as in the chain's flat_set
+func sortedProposalIds(ids []int64) []int64 {
+	sorted := make([]int64, 0, len(ids))
+	seen := make(map[int64]bool)
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			sorted = append(sorted, id)
+		}
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i] < sorted[j]
+	})
+	return sorted
 }
 
 // ref: https://developers.hive.io/apidefinitions/#broadcast_ops_account_update
@@ -71,9 +1041,9 @@ type AccountUpdateOperation struct {
 	Account string `json:"account"`
 
 	// optional: auths
-	Owner   *Auths `json:"owner"`
-	Active  *Auths `json:"active"`
-	Posting *Auths `json:"posting"`
+	Owner   *Authority `json:"owner"`
+	Active  *Authority `json:"active"`
+	Posting *Authority `json:"posting"`
 
 	MemoKey      string `json:"memo_key"`
 	JsonMetadata string `json:"json_metadata"`
@@ -89,16 +1059,21 @@ func (o AccountUpdateOperation) OpName() string {
 // Broadcast Account update operation
 func (h *HiveRpcNode) UpdateAccount(
 	account string,
-	owner *Auths,
-	active *Auths,
-	posting *Auths,
+	owner *Authority,
+	active *Authority,
+	posting *Authority,
 	jsonMetadata string,
 	memoKey string,
 	wif *string,
 ) (string, error) {
 
-	if owner != nil || active != nil || posting != nil {
-		return "", fmt.Errorf("owner, active, posting are not supported or tested yet")
+	for _, auth := range []*Authority{owner, active, posting} {
+		if auth == nil {
+			continue
+		}
+		if err := auth.Validate(); err != nil {
+			return "", err
+		}
 	}
 
 	op := AccountUpdateOperation{
@@ -113,6 +1088,203 @@ func (h *HiveRpcNode) UpdateAccount(
 	return h.Broadcast([]HiveOperation{op}, wif)
 }
 
+// account_update2 changes the authorities, memo key and both metadata fields.
+// Unset authorities and memo key and empty metadata are left unchanged, so
+// updating only posting_json_metadata requires just the posting key.
+type AccountUpdate2Operation struct {
+	Account             string     `json:"account"`
+	Owner               *Authority `json:"owner,omitempty"`
+	Active              *Authority `json:"active,omitempty"`
+	Posting             *Authority `json:"posting,omitempty"`
+	MemoKey             string     `json:"memo_key,omitempty"`
+	JsonMetadata        string     `json:"json_metadata"`
+	PostingJsonMetadata string     `json:"posting_json_metadata"`
+	Extensions          Extensions `json:"extensions"`
+}
+
+func (o AccountUpdate2Operation) OpName() string {
+	return "account_update2"
+}
+
+// Broadcast the posting_json_metadata of an account, signed with the posting key
+func (h *HiveRpcNode) UpdatePostingJsonMetadata(account string, postingJsonMetadata string, postingWif *string) (string, error) {
+	op := AccountUpdate2Operation{
+		Account:             account,
+		PostingJsonMetadata: postingJsonMetadata,
+		Extensions:          Extensions{},
+	}
+
+	return h.Broadcast([]HiveOperation{op}, postingWif)
+}
+
+type AccountCreateOperation struct {
+	Fee            Asset     `json:"fee"`
+	Creator        string    `json:"creator"`
+	NewAccountName string    `json:"new_account_name"`
+	Owner          Authority `json:"owner"`
+	Active         Authority `json:"active"`
+	Posting        Authority `json:"posting"`
+	MemoKey        string    `json:"memo_key"`
+	JsonMetadata   string    `json:"json_metadata"`
+}
+
+func (o AccountCreateOperation) OpName() string {
+	return "account_create"
+}
+
+// Deprecated by the chain since hardfork 20, kept for decoding old blocks and
+// for chains that still accept it
+type AccountCreateWithDelegationOperation struct {
+	Fee            Asset      `json:"fee"`
+	Delegation     Asset      `json:"delegation"`
+	Creator        string     `json:"creator"`
+	NewAccountName string     `json:"new_account_name"`
+	Owner          Authority  `json:"owner"`
+	Active         Authority  `json:"active"`
+	Posting        Authority  `json:"posting"`
+	MemoKey        string     `json:"memo_key"`
+	JsonMetadata   string     `json:"json_metadata"`
+	Extensions     Extensions `json:"extensions"`
+}
+
+func (o AccountCreateWithDelegationOperation) OpName() string {
+	return "account_create_with_delegation"
+}
+
+type ClaimAccountOperation struct {
+	Creator    string     `json:"creator"`
+	Fee        Asset      `json:"fee"`
+	Extensions Extensions `json:"extensions"`
+}
+
+func (o ClaimAccountOperation) OpName() string {
+	return "claim_account"
+}
+
+type CreateClaimedAccountOperation struct {
+	Creator        string     `json:"creator"`
+	NewAccountName string     `json:"new_account_name"`
+	Owner          Authority  `json:"owner"`
+	Active         Authority  `json:"active"`
+	Posting        Authority  `json:"posting"`
+	MemoKey        string     `json:"memo_key"`
+	JsonMetadata   string     `json:"json_metadata"`
+	Extensions     Extensions `json:"extensions"`
+}
+
+func (o CreateClaimedAccountOperation) OpName() string {
+	return "create_claimed_account"
+}
+
+// Claim an account creation token. A fee of "0.000 HIVE" pays with RC instead of HIVE.
+func (h *HiveRpcNode) ClaimAccount(creator string, fee string, wif *string) (string, error) {
+	feeAsset, err := parseAssetOf(fee, "HIVE", "TESTS")
+	if err != nil {
+		return "", err
+	}
+	op := ClaimAccountOperation{creator, feeAsset, Extensions{}}
+
+	return h.Broadcast([]HiveOperation{op}, wif)
+}
+
+// Create an account with newly generated keys, which are returned. A pending
+// claimed account token of the creator is used when there is one, otherwise
+// the account creation fee of the chain properties is paid.
+func (h *HiveRpcNode) CreateAccount(creator string, newAccountName string, wif *string) (string, *AccountKeys, error) {
+	keys, err := GenerateAccountKeys()
+	if err != nil {
+		return "", nil, err
+	}
+
+	txId, err := h.CreateAccountWithKeys(creator, newAccountName, keys, wif)
+	if err != nil {
+		return txId, nil, err
+	}
+	return txId, keys, nil
+}
+
+// Create an account with the given keys, see CreateAccount
+func (h *HiveRpcNode) CreateAccountWithKeys(creator string, newAccountName string, keys *AccountKeys, wif *string) (string, error) {
+	creatorData, err := h.getSingleAccount(creator)
+	if err != nil {
+		return "", err
+	}
+
+	owner := NewKeyAuthority(*keys.Owner.GetPublicKeyString())
+	active := NewKeyAuthority(*keys.Active.GetPublicKeyString())
+	posting := NewKeyAuthority(*keys.Posting.GetPublicKeyString())
+	memoKey := *keys.Memo.GetPublicKeyString()
+
+	var op HiveOperation
+	if creatorData.PendingClaimedAccounts > 0 {
+		op = CreateClaimedAccountOperation{creator, newAccountName, owner, active, posting, memoKey, "", Extensions{}}
+	} else {
+		props, err := h.GetChainProperties()
+		if err != nil {
+			return "", err
+		}
+		op = AccountCreateOperation{props.AccountCreationFee, creator, newAccountName, owner, active, posting, memoKey, ""}
+	}
+
+	return h.Broadcast([]HiveOperation{op}, wif)
+}
+
+// Returns the median chain properties voted by the witnesses
+func (h *HiveRpcNode) GetChainProperties() (ChainProperties, error) {
+	q := hrpcQuery{method: "condenser_api.get_chain_properties", params: []string{}}
+	res, err := h.rpcExec(q)
+	if err != nil {
+		return ChainProperties{}, err
+	}
+
+	var props ChainProperties
+	err = json.Unmarshal(res, &props)
+	if err != nil {
+		return ChainProperties{}, err
+	}
+	return props, nil
+}
+
+type RequestAccountRecoveryOperation struct {
+	RecoveryAccount   string     `json:"recovery_account"`
+	AccountToRecover  string     `json:"account_to_recover"`
+	NewOwnerAuthority Authority  `json:"new_owner_authority"`
+	Extensions        Extensions `json:"extensions"`
+}
+
+func (o RequestAccountRecoveryOperation) OpName() string {
+	return "request_account_recovery"
+}
+
+type RecoverAccountOperation struct {
+	AccountToRecover     string     `json:"account_to_recover"`
+	NewOwnerAuthority    Authority  `json:"new_owner_authority"`
+	RecentOwnerAuthority Authority  `json:"recent_owner_authority"`
+	Extensions           Extensions `json:"extensions"`
+}
+
+func (o RecoverAccountOperation) OpName() string {
+	return "recover_account"
+}
+
+type ChangeRecoveryAccountOperation struct {
+	AccountToRecover   string     `json:"account_to_recover"`
+	NewRecoveryAccount string     `json:"new_recovery_account"`
+	Extensions         Extensions `json:"extensions"`
+}
+
+func (o ChangeRecoveryAccountOperation) OpName() string {
+	return "change_recovery_account"
+}
+
+// Change the recovery account, signed with the owner key. The change takes
+// effect after 30 days.
+func (h *HiveRpcNode) ChangeRecoveryAccount(account string, newRecoveryAccount string, ownerWif *string) (string, error) {
+	op := ChangeRecoveryAccountOperation{account, newRecoveryAccount, Extensions{}}
+
+	return h.Broadcast([]HiveOperation{op}, ownerWif)
+}
+
 type CustomJsonOperation struct {
 	RequiredAuths        []string `json:"required_auths"`
 	RequiredPostingAuths []string `json:"required_posting_auths"`
@@ -130,11 +1302,77 @@ func (h *HiveRpcNode) BroadcastJson(reqAuth []string, reqPostAuth []string, id s
 	return h.Broadcast([]HiveOperation{op}, wif)
 }
 
+// Binary payload of custom and custom_binary, hex encoded in JSON
+type HexBytes []byte
+
+func (b HexBytes) MarshalJSON() ([]byte, error) {
+	return json.Marshal(hex.EncodeToString(b))
+}
+
+func (b *HexBytes) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	decoded, err := hex.DecodeString(s)
+	if err != nil {
+		return err
+	}
+	*b = decoded
+	return nil
+}
+
+type CustomOperation struct {
+	RequiredAuths []string `json:"required_auths"`
+	Id            uint16   `json:"id"`
+	Data          HexBytes `json:"data"`
+}
+
+func (o CustomOperation) OpName() string {
+	return "custom"
+}
+
+func (h *HiveRpcNode) BroadcastCustom(reqAuth []string, id uint16, data []byte, wif *string) (string, error) {
+	op := CustomOperation{reqAuth, id, data}
+	return h.Broadcast([]HiveOperation{op}, wif)
+}
+
+type CustomBinaryOperation struct {
+	RequiredOwnerAuths   []string    `json:"required_owner_auths"`
+	RequiredActiveAuths  []string    `json:"required_active_auths"`
+	RequiredPostingAuths []string    `json:"required_posting_auths"`
+	RequiredAuths        []Authority `json:"required_auths"`
+	Id                   string      `json:"id"`
+	Data                 HexBytes    `json:"data"`
+}
+
+func (o CustomBinaryOperation) OpName() string {
+	return "custom_binary"
+}
+
+func (h *HiveRpcNode) BroadcastCustomBinary(op CustomBinaryOperation, wif *string) (string, error) {
+	return h.Broadcast([]HiveOperation{op}, wif)
+}
+
+// Decodes a custom_operation found in a block
+func DecodeCustomOperation(op Operation) (CustomOperation, error) {
+	var custom CustomOperation
+	err := decodeOperationValue(op, OperationType.Custom, &custom)
+	return custom, err
+}
+
+// Decodes a custom_binary_operation found in a block
+func DecodeCustomBinaryOperation(op Operation) (CustomBinaryOperation, error) {
+	var custom CustomBinaryOperation
+	err := decodeOperationValue(op, OperationType.CustomBinary, &custom)
+	return custom, err
+}
+
 type ClaimRewardOperation struct {
 	Account     string `json:"account"`
-	RewardHBD   string `json:"reward_hbd"`
-	RewardHIVE  string `json:"reward_hive"`
-	RewardVests string `json:"reward_vests"`
+	RewardHBD   Asset  `json:"reward_hbd"`
+	RewardHIVE  Asset  `json:"reward_hive"`
+	RewardVests Asset  `json:"reward_vests"`
 	opText      string
 }
 
@@ -162,7 +1400,7 @@ func (h *HiveRpcNode) ClaimRewards(Account string, wif *string) (string, error)
 type TransferOperation struct {
 	From   string `json:"from"`
 	To     string `json:"to"`
-	Amount string `json:"amount"`
+	Amount Asset  `json:"amount"`
 	Memo   string `json:"memo"`
 }
 
@@ -171,11 +1409,100 @@ func (o TransferOperation) OpName() string {
 }
 
 func (h *HiveRpcNode) Transfer(from string, to string, amount string, memo string, wif *string) (string, error) {
-	transfer := TransferOperation{from, to, amount, memo}
+	asset, err := parseAssetOf(amount, "HIVE", "HBD", "TESTS", "TBD")
+	if err != nil {
+		return "", err
+	}
+	transfer := TransferOperation{from, to, asset, memo}
 
 	return h.Broadcast([]HiveOperation{transfer}, wif)
 }
 
+// recurrent_transfer_pair_id extension of recurrent_transfer, which lets a
+// sender hold several recurrent transfers to the same recipient
+type RecurrentTransferPairId struct {
+	PairId uint8 `json:"pair_id"`
+}
+
+func (e RecurrentTransferPairId) ExtensionId() uint64 {
+	return 0
+}
+
+func (e RecurrentTransferPairId) MarshalJSON() ([]byte, error) {
+	return json.Marshal([2]interface{}{e.ExtensionId(), struct {
+		PairId uint8 `json:"pair_id"`
+	}{e.PairId}})
+}
+
+type RecurrentTransferOperation struct {
+	From       string     `json:"from"`
+	To         string     `json:"to"`
+	Amount     Asset      `json:"amount"`
+	Memo       string     `json:"memo"`
+	Recurrence uint16     `json:"recurrence"`
+	Executions uint16     `json:"executions"`
+	Extensions Extensions `json:"extensions"`
+}
+
+func (o RecurrentTransferOperation) OpName() string {
+	return "recurrent_transfer"
+}
+
+const (
+	// minimum hours between two executions of a recurrent transfer
+	minRecurrence = 24
+	// HIVE_MAX_RECURRENT_TRANSFER_END_DATE: a recurrent transfer can't run
+	// for more than two years
+	maxRecurrentTransferDays = 730
+)
+
+// Create or update the recurrent transfer of from to to identified by pairId,
+// executed every recurrence hours, executions times. The first execution
+// happens right away.
+func (h *HiveRpcNode) RecurrentTransfer(from string, to string, amount string, memo string, recurrence uint16, executions uint16, pairId uint8, wif *string) (string, error) {
+	asset, err := parseAssetOf(amount, "HIVE", "HBD", "TESTS", "TBD")
+	if err != nil {
+		return "", err
+	}
+	if err := checkRecurrentTransferSchedule(recurrence, executions); err != nil {
+		return "", err
+	}
+
+	op := RecurrentTransferOperation{from, to, asset, memo, recurrence, executions, recurrentTransferExtensions(pairId)}
+	return h.Broadcast([]HiveOperation{op}, wif)
+}
+
+// Checks the schedule the way hived's recurrent_transfer validation does,
+// which counts recurrence * executions hours, in whole days, against the
+// limit
+func checkRecurrentTransferSchedule(recurrence uint16, executions uint16) error {
+	if recurrence < minRecurrence {
+		return fmt.Errorf("recurrence must be at least %d hours", minRecurrence)
+	}
+	if executions < 2 {
+		return errors.New("a recurrent transfer needs at least 2 executions")
+	}
+	if int(recurrence)*int(executions)/24 > maxRecurrentTransferDays {
+		return fmt.Errorf("a recurrent transfer can't last more than %d days", maxRecurrentTransferDays)
+	}
+	return nil
+}
+
+// Cancel the recurrent transfer of from to to identified by pairId
+func (h *HiveRpcNode) CancelRecurrentTransfer(from string, to string, pairId uint8, wif *string) (string, error) {
+	op := RecurrentTransferOperation{from, to, MustParseAsset("0.000 HIVE"), "", minRecurrence, 2, recurrentTransferExtensions(pairId)}
+
+	return h.Broadcast([]HiveOperation{op}, wif)
+}
+
+// pair id 0 is the default and is left out
+func recurrentTransferExtensions(pairId uint8) Extensions {
+	if pairId == 0 {
+		return Extensions{}
+	}
+	return Extensions{RecurrentTransferPairId{pairId}}
+}
+
 func getHiveChainId() []byte {
 	cid, _ := hex.DecodeString("beeab0de00000000000000000000000000000000000000000000000000000000")
 	return cid
@@ -1,9 +1,11 @@
 package hivego
 
 import (
+	"encoding/json"
 	"errors"
 	"log"
 	"sync"
+	"time"
 
 	"github.com/cfoxon/jsonrpc2client"
 )
@@ -23,12 +25,26 @@ type HiveRpcNode struct {
 	MaxBatch     int
 	NoBroadcast  bool
 	ChainID      string
+	// Broadcast HF26 JSON through network_broadcast_api instead of legacy
+	// JSON through condenser_api, and read the global properties needed to
+	// sign from database_api, for nodes that only serve appbase APIs
+	UseNetworkBroadcast bool
+
+	propsMutex   sync.Mutex
+	cachedProps  *GlobalProps
+	propsFetched time.Time
+
+	convRequestMutex sync.Mutex
+	convRequestIds   map[string]uint32
 }
 
-type globalProps struct {
-	HeadBlockNumber int    `json:"head_block_number"`
-	HeadBlockId     string `json:"head_block_id"`
-	Time            string `json:"time"`
+type GlobalProps struct {
+	HeadBlockNumber          int    `json:"head_block_number"`
+	HeadBlockId              string `json:"head_block_id"`
+	Time                     string `json:"time"`
+	LastIrreversibleBlockNum int    `json:"last_irreversible_block_num"`
+	TotalVestingFundHive     Asset  `json:"total_vesting_fund_hive"`
+	TotalVestingShares       Asset  `json:"total_vesting_shares"`
 }
 
 type hrpcQuery struct {
@@ -53,6 +69,10 @@ func NewHiveRpcWithOpts(addrs []string, maxConn int, maxBatch int) *HiveRpcNode
 
 func (h *HiveRpcNode) GetDynamicGlobalProps() ([]byte, error) {
 	q := hrpcQuery{method: "condenser_api.get_dynamic_global_properties", params: []string{}}
+	if h.UseNetworkBroadcast {
+		// same fields, with assets in the NAI form
+		q = hrpcQuery{method: "database_api.get_dynamic_global_properties", params: struct{}{}}
+	}
 	res, err := h.rpcExec(q)
 	if err != nil {
 		return nil, err
@@ -60,6 +80,42 @@ func (h *HiveRpcNode) GetDynamicGlobalProps() ([]byte, error) {
 	return res, nil
 }
 
+// Fetches and parses the dynamic global properties. The result is cached on
+// the node for GetCachedGlobalProps.
+func (h *HiveRpcNode) GetGlobalProps() (GlobalProps, error) {
+	propsB, err := h.GetDynamicGlobalProps()
+	if err != nil {
+		return GlobalProps{}, err
+	}
+
+	var props GlobalProps
+	err = json.Unmarshal(propsB, &props)
+	if err != nil {
+		return GlobalProps{}, err
+	}
+
+	h.propsMutex.Lock()
+	h.cachedProps = &props
+	h.propsFetched = time.Now()
+	h.propsMutex.Unlock()
+
+	return props, nil
+}
+
+// Returns the last fetched global properties if they are younger than maxAge,
+// otherwise fetches them again
+func (h *HiveRpcNode) GetCachedGlobalProps(maxAge time.Duration) (GlobalProps, error) {
+	h.propsMutex.Lock()
+	if h.cachedProps != nil && time.Since(h.propsFetched) < maxAge {
+		props := *h.cachedProps
+		h.propsMutex.Unlock()
+		return props, nil
+	}
+	h.propsMutex.Unlock()
+
+	return h.GetGlobalProps()
+}
+
 func (h *HiveRpcNode) rpcExec(query hrpcQuery) ([]byte, error) {
 	h.mutex.Lock()
 	defer h.mutex.Unlock()
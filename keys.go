@@ -2,7 +2,9 @@ package hivego
 
 import (
 	"bytes"
+	"crypto/sha256"
 	"errors"
+	"strings"
 
 	"github.com/decred/base58"
 	"github.com/decred/dcrd/dcrec/secp256k1/v2"
@@ -37,10 +39,68 @@ func KeyPairFromBytes(privKey []byte) *KeyPair {
 	return &KeyPair{prvKey, pubKey}
 }
 
+// Generates a new random KeyPair
+func GenerateKeyPair() (*KeyPair, error) {
+	prvKey, err := secp256k1.GeneratePrivateKey()
+	if err != nil {
+		return nil, err
+	}
+
+	return &KeyPair{prvKey, prvKey.PubKey()}, nil
+}
+
+// Derives the KeyPair of a role (owner, active, posting or memo) from an
+// account password, the same way as the Hive wallets do
+func KeyPairFromPassword(account string, role string, password string) *KeyPair {
+	seed := sha256.Sum256([]byte(account + role + password))
+
+	return KeyPairFromBytes(seed[:])
+}
+
+// Encodes the private key in the WIF format
+func (kp *KeyPair) ToWif() string {
+	payload := append([]byte{0x80}, kp.PrivateKey.Serialize()...)
+	checksum := checksum(payload)
+
+	return base58.Encode(append(payload, checksum[:]...))
+}
+
+// The keys of the four roles of an account
+type AccountKeys struct {
+	Owner   *KeyPair
+	Active  *KeyPair
+	Posting *KeyPair
+	Memo    *KeyPair
+}
+
+// Generates random keys for every role
+func GenerateAccountKeys() (*AccountKeys, error) {
+	var keys [4]*KeyPair
+	for i := range keys {
+		kp, err := GenerateKeyPair()
+		if err != nil {
+			return nil, err
+		}
+		keys[i] = kp
+	}
+
+	return &AccountKeys{keys[0], keys[1], keys[2], keys[3]}, nil
+}
+
+// Derives the keys of every role from an account password
+func AccountKeysFromPassword(account string, password string) *AccountKeys {
+	return &AccountKeys{
+		Owner:   KeyPairFromPassword(account, "owner", password),
+		Active:  KeyPairFromPassword(account, "active", password),
+		Posting: KeyPairFromPassword(account, "posting", password),
+		Memo:    KeyPairFromPassword(account, "memo", password),
+	}
+}
+
 // Decodes a base58 Hive public key to secp256k1 public key
 func DecodePublicKey(pubKey string) (*secp256k1.PublicKey, error) {
 	// check prefix matches
-	if pubKey[:len(PublicKeyPrefix)] != PublicKeyPrefix {
+	if !strings.HasPrefix(pubKey, PublicKeyPrefix) {
 		return nil, errors.New("invalid prefix")
 	}
 
@@ -49,6 +109,9 @@ func DecodePublicKey(pubKey string) (*secp256k1.PublicKey, error) {
 
 	// decode base58
 	decoded := base58.Decode(pubKey)
+	if len(decoded) != 37 {
+		return nil, errors.New("invalid public key length")
+	}
 
 	// get checksum
 	checksum := decoded[len(decoded)-4:]
@@ -0,0 +1,299 @@
+package hivego
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v2"
+)
+
+// A transaction collecting signatures from co-signers on different machines,
+// for accounts with a multisig authority. It marshals to JSON so it can be
+// passed around between the signers:
+//
+//	{"chain_id": "beeab0de...", "expiration": "2024-01-02T00:00:00",
+//	 "transaction": "<hex serialized transaction>",
+//	 "authority": {"weight_threshold": 2, ...}, "signatures": ["1f..."]}
+//
+// Each signer adds their signature with Sign, AddSig or Merge, which all
+// verify that the signatures are by keys of Authority, and the last one
+// broadcasts with Finalize.
+type PartialTransaction struct {
+	ChainId     string   `json:"chain_id"`
+	Expiration  string   `json:"expiration"`
+	Transaction HexBytes `json:"transaction"`
+	// authority the co-signers' keys belong to
+	Authority  Authority `json:"authority"`
+	Signatures []string  `json:"signatures"`
+
+	// public keys of the signatures, in the same order
+	signers []string
+	// digests of the HF26 and the legacy serialization, see digests
+	sigDigests [][]byte
+}
+
+// Returns the partially signed form of tx for the given chain id (mainnet if
+// empty) to be signed by keys of auth, keeping the signatures tx already has
+func NewPartialTransaction(tx HiveTransaction, chainId string, auth Authority) (*PartialTransaction, error) {
+	if chainId == "" {
+		chainId = hex.EncodeToString(getHiveChainId())
+	}
+	if _, err := hex.DecodeString(chainId); err != nil {
+		return nil, fmt.Errorf("invalid chain id: %w", err)
+	}
+	txB, err := SerializeTx(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &PartialTransaction{
+		ChainId:     chainId,
+		Expiration:  tx.Expiration,
+		Transaction: txB,
+		Authority:   auth,
+		Signatures:  []string{},
+	}
+	for _, sig := range tx.Signatures {
+		if err := p.AddSig(sig); err != nil {
+			return nil, err
+		}
+	}
+	return p, nil
+}
+
+func (p *PartialTransaction) UnmarshalJSON(b []byte) error {
+	type partialTransaction PartialTransaction
+	var raw partialTransaction
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+	tx, err := DeserializeTx(raw.Transaction, raw.ChainId)
+	if err != nil {
+		return err
+	}
+	if tx.Expiration != raw.Expiration {
+		return fmt.Errorf("expiration %s does not match the transaction expiring %s", raw.Expiration, tx.Expiration)
+	}
+
+	*p = PartialTransaction{
+		ChainId:     raw.ChainId,
+		Expiration:  raw.Expiration,
+		Transaction: raw.Transaction,
+		Authority:   raw.Authority,
+		Signatures:  []string{},
+	}
+	// verified again, they might have been tampered with on the way
+	for _, sig := range raw.Signatures {
+		if err := p.AddSig(sig); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Returns the transaction with the signatures collected so far
+func (p *PartialTransaction) Tx() (HiveTransaction, error) {
+	tx, err := DeserializeTx(p.Transaction, p.ChainId)
+	if err != nil {
+		return HiveTransaction{}, err
+	}
+	tx.Signatures = append([]string{}, p.Signatures...)
+	return tx, nil
+}
+
+// Returns the digests signatures are checked against: the chain accepts
+// signatures over the HF26 and the legacy serialization, and most tools
+// other than hivego sign the legacy one
+func (p *PartialTransaction) digests() ([][]byte, error) {
+	if p.sigDigests != nil {
+		return p.sigDigests, nil
+	}
+	tx, err := DeserializeTx(p.Transaction, p.ChainId)
+	if err != nil {
+		return nil, err
+	}
+	hf26, err := SerializeTx(tx)
+	if err != nil {
+		return nil, err
+	}
+	legacy, err := legacySerialization(hf26, p.ChainId)
+	if err != nil {
+		return nil, err
+	}
+	p.sigDigests = [][]byte{HashTxForSig(hf26, p.ChainId), HashTxForSig(legacy, p.ChainId)}
+	return p.sigDigests, nil
+}
+
+// Returns the key of Authority that made sig over either serialization
+func (p *PartialTransaction) recoverSigner(sig string) (string, error) {
+	digests, err := p.digests()
+	if err != nil {
+		return "", err
+	}
+	for _, digest := range digests {
+		signer, err := RecoverPublicKey(digest, sig)
+		if err != nil {
+			return "", err
+		}
+		for _, keyAuth := range p.Authority.KeyAuths {
+			if keyAuth.Key == signer {
+				return signer, nil
+			}
+		}
+	}
+	return "", fmt.Errorf("signature %s is not by a key of the authority", sig)
+}
+
+// Returns the public keys that signed the transaction so far
+func (p *PartialTransaction) Signers() []string {
+	return append([]string{}, p.signers...)
+}
+
+// Signs the HF26 serialization of the transaction with each of keyPairs
+func (p *PartialTransaction) Sign(keyPairs ...*KeyPair) error {
+	digests, err := p.digests()
+	if err != nil {
+		return err
+	}
+	for _, keyPair := range keyPairs {
+		sig, err := secp256k1.SignCompact(keyPair.PrivateKey, digests[0], true)
+		if err != nil {
+			return err
+		}
+		if err := p.AddSig(hex.EncodeToString(sig)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Adds a hex signature over either serialization of the transaction. Its
+// key must be one of Authority that has not signed already, signatures of
+// other keys or transactions are rejected.
+func (p *PartialTransaction) AddSig(sig string) error {
+	signer, err := p.recoverSigner(sig)
+	if err != nil {
+		return err
+	}
+	if p.signedBy(signer) {
+		return fmt.Errorf("%s already signed the transaction", signer)
+	}
+	p.Signatures = append(p.Signatures, sig)
+	p.signers = append(p.signers, signer)
+	return nil
+}
+
+// Adds the signatures of other, a copy of the same transaction signed by
+// other co-signers. Signatures of keys that already signed are skipped, so
+// copies that started out with the same signatures can be merged, those of
+// keys outside Authority are rejected.
+func (p *PartialTransaction) Merge(other *PartialTransaction) error {
+	if p.ChainId != other.ChainId || !bytes.Equal(p.Transaction, other.Transaction) {
+		return errors.New("can't merge signatures of a different transaction")
+	}
+	for _, sig := range other.Signatures {
+		signer, err := p.recoverSigner(sig)
+		if err != nil {
+			return err
+		}
+		if p.signedBy(signer) {
+			continue
+		}
+		p.Signatures = append(p.Signatures, sig)
+		p.signers = append(p.signers, signer)
+	}
+	return nil
+}
+
+func (p *PartialTransaction) signedBy(key string) bool {
+	for _, signer := range p.signers {
+		if signer == key {
+			return true
+		}
+	}
+	return false
+}
+
+// Returns the weight the signatures have in auth, counting key_auths only
+func (p *PartialTransaction) SignedWeight(auth Authority) uint32 {
+	var weight uint32
+	for _, keyAuth := range auth.KeyAuths {
+		if p.signedBy(keyAuth.Key) {
+			weight += uint32(keyAuth.Weight)
+		}
+	}
+	return weight
+}
+
+// Broadcasts the transaction through BroadcastRaw once the signatures reach
+// the weight threshold of auth, the authority the transaction needs. The
+// chain rejects signatures it doesn't need, so only those reaching the
+// threshold are broadcast, in the order the chain counts the keys, and
+// signatures of keys outside auth are an error.
+func (p *PartialTransaction) Finalize(h *HiveRpcNode, auth Authority) (string, error) {
+	chainId := h.ChainID
+	if chainId == "" {
+		chainId = hex.EncodeToString(getHiveChainId())
+	}
+	if p.ChainId != chainId {
+		return "", fmt.Errorf("transaction is for chain %s, the node is on %s", p.ChainId, chainId)
+	}
+	sigs, err := p.neededSignatures(auth)
+	if err != nil {
+		return "", err
+	}
+	tx, err := p.Tx()
+	if err != nil {
+		return "", err
+	}
+	tx.Signatures = sigs
+	expiration, err := time.Parse(customTimeLayout, tx.Expiration)
+	if err != nil {
+		return "", err
+	}
+	if time.Now().After(expiration) {
+		return "", fmt.Errorf("transaction expired at %s", tx.Expiration)
+	}
+	return h.BroadcastRaw(tx)
+}
+
+// Returns the signatures the chain counts toward the threshold of auth, as it
+// walks the keys in serialized order and stops at the threshold. Signatures
+// of keys outside auth are an error, as the chain would reject them.
+func (p *PartialTransaction) neededSignatures(auth Authority) ([]string, error) {
+	keyAuths, err := auth.sortedKeyAuths()
+	if err != nil {
+		return nil, err
+	}
+	members := make(map[string]bool, len(keyAuths))
+	for _, keyAuth := range keyAuths {
+		members[keyAuth.pubKey] = true
+	}
+	for _, signer := range p.signers {
+		if !members[signer] {
+			return nil, fmt.Errorf("%s signed but is not a key of the authority", signer)
+		}
+	}
+
+	var sigs []string
+	var weight uint32
+	for _, keyAuth := range keyAuths {
+		if weight >= auth.Threshold {
+			break
+		}
+		for i, signer := range p.signers {
+			if signer == keyAuth.pubKey {
+				sigs = append(sigs, p.Signatures[i])
+				weight += uint32(keyAuth.weight)
+			}
+		}
+	}
+	if weight < auth.Threshold {
+		return nil, fmt.Errorf("signatures have weight %d of the %d needed", weight, auth.Threshold)
+	}
+	return sigs, nil
+}
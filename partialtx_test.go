@@ -0,0 +1,193 @@
+package hivego
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v2"
+)
+
+func getTestPartialTx(t *testing.T, auth Authority) *PartialTransaction {
+	tx := getTestTx([]HiveOperation{getTestTransferOp()})
+	tx.Expiration = time.Now().Add(time.Hour).UTC().Format(customTimeLayout)
+	p, err := NewPartialTransaction(tx, "", auth)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return p
+}
+
+func TestPartialTransaction(t *testing.T) {
+	alice := KeyPairFromPassword("alice", "active", "pass")
+	bob := KeyPairFromPassword("bob", "active", "pass")
+	auth := Authority{
+		Threshold: 2,
+		KeyAuths:  []KeyAuth{{*alice.GetPublicKeyString(), 1}, {*bob.GetPublicKeyString(), 1}},
+	}
+
+	// each co-signer signs their own copy, passed around as JSON
+	p := getTestPartialTx(t, auth)
+	b, err := json.Marshal(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var other PartialTransaction
+	if err := json.Unmarshal(b, &other); err != nil {
+		t.Fatal(err)
+	}
+	if err := p.Sign(alice); err != nil {
+		t.Fatal(err)
+	}
+	if err := other.Sign(bob); err != nil {
+		t.Fatal(err)
+	}
+	if err := p.AddSig(p.Signatures[0]); err == nil {
+		t.Error("Expected an error for a duplicate signature")
+	}
+	if err := p.AddSig("1f00"); err == nil {
+		t.Error("Expected an error for an invalid signature")
+	}
+
+	h := NewHiveRpc([]string{"https://api.hive.blog"})
+	h.NoBroadcast = true
+	if _, err := p.Finalize(h, auth); err == nil {
+		t.Error("Expected an error below the threshold")
+	}
+
+	if err := p.Merge(&other); err != nil {
+		t.Fatal(err)
+	}
+	if err := p.Merge(&other); err != nil {
+		t.Fatal(err)
+	}
+	if len(p.Signatures) != 2 || p.SignedWeight(auth) != 2 {
+		t.Error("Expected 2 signatures of weight 2, got", p.Signatures, p.SignedWeight(auth))
+	}
+	signers := p.Signers()
+	if signers[0] != *alice.GetPublicKeyString() || signers[1] != *bob.GetPublicKeyString() {
+		t.Error("Unexpected signers", signers)
+	}
+
+	tx, err := p.Tx()
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectedId, _ := tx.GenerateTrxId()
+	txId, err := p.Finalize(h, auth)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if txId != expectedId {
+		t.Error("Expected", expectedId, "got", txId)
+	}
+}
+
+func TestPartialTransactionLegacySignature(t *testing.T) {
+	alice := KeyPairFromPassword("alice", "active", "pass")
+	bob := KeyPairFromPassword("bob", "active", "pass")
+	auth := Authority{
+		Threshold: 2,
+		KeyAuths:  []KeyAuth{{*alice.GetPublicKeyString(), 1}, {*bob.GetPublicKeyString(), 1}},
+	}
+	p := getTestPartialTx(t, auth)
+	if err := p.Sign(alice); err != nil {
+		t.Fatal(err)
+	}
+
+	// bob signs the legacy serialization, as tools other than hivego do
+	legacy, err := legacySerialization(p.Transaction, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	sig, _ := secp256k1.SignCompact(bob.PrivateKey, HashTxForSig(legacy), true)
+	if err := p.AddSig(hex.EncodeToString(sig)); err != nil {
+		t.Fatal(err)
+	}
+	if p.SignedWeight(auth) != 2 {
+		t.Error("Expected weight 2, got", p.SignedWeight(auth))
+	}
+}
+
+func TestPartialTransactionForeignSignatures(t *testing.T) {
+	alice := KeyPairFromPassword("alice", "active", "pass")
+	bob := KeyPairFromPassword("bob", "active", "pass")
+	carol := KeyPairFromPassword("carol", "active", "pass")
+	auth := Authority{
+		Threshold: 1,
+		KeyAuths:  []KeyAuth{{*alice.GetPublicKeyString(), 1}, {*bob.GetPublicKeyString(), 1}},
+	}
+
+	p := getTestPartialTx(t, auth)
+	if err := p.Sign(carol); err == nil {
+		t.Error("Expected an error for a key outside the authority")
+	}
+	other := getTestPartialTx(t, Authority{Threshold: 1, KeyAuths: []KeyAuth{{*carol.GetPublicKeyString(), 1}}})
+	if err := other.Sign(carol); err != nil {
+		t.Fatal(err)
+	}
+	if err := p.Merge(other); err == nil {
+		t.Error("Expected an error merging a signature of a key outside the authority")
+	}
+	if len(p.Signatures) != 0 {
+		t.Error("Expected no signatures, got", p.Signatures)
+	}
+
+	// signatures past the threshold are dropped, the chain rejects them
+	if err := p.Sign(alice, bob); err != nil {
+		t.Fatal(err)
+	}
+	sigs, err := p.neededSignatures(auth)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sigs) != 1 {
+		t.Error("Expected a single signature, got", sigs)
+	}
+
+	// finalizing for an authority the signers aren't all in fails
+	h := NewHiveRpc([]string{"https://api.hive.blog"})
+	h.NoBroadcast = true
+	if err := other.Merge(p); err == nil {
+		t.Error("Expected an error merging signatures outside the authority")
+	}
+	if _, err := other.Finalize(h, Authority{Threshold: 1, KeyAuths: []KeyAuth{{*alice.GetPublicKeyString(), 1}}}); err == nil {
+		t.Error("Expected an error for a foreign signature")
+	}
+	if _, err := p.Finalize(h, auth); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestPartialTransactionErrors(t *testing.T) {
+	p := getTestPartialTx(t, Authority{})
+	other := getTestPartialTx(t, Authority{})
+	other.Transaction = append(HexBytes{}, other.Transaction...)
+	other.Transaction[0]++
+	if err := p.Merge(other); err == nil {
+		t.Error("Expected an error merging a different transaction")
+	}
+
+	b, _ := json.Marshal(p)
+	var tampered map[string]interface{}
+	json.Unmarshal(b, &tampered)
+	tampered["signatures"] = []string{"zz"}
+	b, _ = json.Marshal(tampered)
+	if err := json.Unmarshal(b, &PartialTransaction{}); err == nil {
+		t.Error("Expected an error for an invalid signature")
+	}
+
+	tampered["signatures"] = []string{}
+	tampered["expiration"] = "2016-08-08T12:24:17"
+	b, _ = json.Marshal(tampered)
+	if err := json.Unmarshal(b, &PartialTransaction{}); err == nil {
+		t.Error("Expected an error for a mismatching expiration")
+	}
+
+	h := NewHiveRpc([]string{"https://api.hive.blog"})
+	h.ChainID = "18dcf0a285365fc58b71f18b3d3fec954aa0c141c44e4e5cb4cf777b9eab274e"
+	if _, err := p.Finalize(h, Authority{Threshold: 1}); err == nil {
+		t.Error("Expected an error for a transaction of another chain")
+	}
+}
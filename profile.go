@@ -0,0 +1,141 @@
+package hivego
+
+import (
+	"encoding/json"
+)
+
+// Hive profile, stored under the "profile" key of posting_json_metadata.
+// Fields that are not known to this type are kept as they are, so editing a
+// profile never drops data written by other applications.
+type Profile struct {
+	Name         string
+	About        string
+	Location     string
+	Website      string
+	ProfileImage string
+	CoverImage   string
+
+	extra map[string]json.RawMessage
+}
+
+// pointers to the known fields by their JSON name
+func (p *Profile) fields() map[string]*string {
+	return map[string]*string{
+		"name":          &p.Name,
+		"about":         &p.About,
+		"location":      &p.Location,
+		"website":       &p.Website,
+		"profile_image": &p.ProfileImage,
+		"cover_image":   &p.CoverImage,
+	}
+}
+
+func (p *Profile) UnmarshalJSON(b []byte) error {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+
+	*p = Profile{extra: make(map[string]json.RawMessage)}
+	fields := p.fields()
+	for k, v := range raw {
+		if field, ok := fields[k]; ok && json.Unmarshal(v, field) == nil {
+			continue
+		}
+		// unknown or not a string: keep the raw value
+		p.extra[k] = v
+	}
+	return nil
+}
+
+func (p Profile) MarshalJSON() ([]byte, error) {
+	out := make(map[string]interface{}, len(p.extra))
+	for k, v := range p.extra {
+		out[k] = v
+	}
+	for k, v := range p.fields() {
+		if *v != "" {
+			out[k] = *v
+		}
+	}
+	return json.Marshal(out)
+}
+
+// Parses the profile of a posting_json_metadata string. An empty or profile
+// less metadata gives an empty profile.
+func ParseProfile(postingJsonMetadata string) (Profile, error) {
+	metadata, err := parseJsonObject(postingJsonMetadata)
+	if err != nil {
+		return Profile{}, err
+	}
+
+	var profile Profile
+	raw, ok := metadata["profile"]
+	if !ok || string(raw) == "null" {
+		return profile, nil
+	}
+	err = json.Unmarshal(raw, &profile)
+	return profile, err
+}
+
+// Returns the profile of the account
+func (a AccountData) Profile() (Profile, error) {
+	return ParseProfile(a.PostingJSONMetadata)
+}
+
+// Returns postingJsonMetadata with its profile replaced by profile, keeping
+// every other key of the metadata
+func SetProfile(postingJsonMetadata string, profile Profile) (string, error) {
+	metadata, err := parseJsonObject(postingJsonMetadata)
+	if err != nil {
+		return "", err
+	}
+
+	profileB, err := json.Marshal(profile)
+	if err != nil {
+		return "", err
+	}
+	metadata["profile"] = profileB
+
+	b, err := json.Marshal(metadata)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// Read-modify-write of an account profile: edit is called with the current
+// profile and the result is broadcast with the posting key
+func (h *HiveRpcNode) UpdateProfile(account string, edit func(*Profile), postingWif *string) (string, error) {
+	accountData, err := h.getSingleAccount(account)
+	if err != nil {
+		return "", err
+	}
+	profile, err := accountData.Profile()
+	if err != nil {
+		return "", err
+	}
+
+	edit(&profile)
+
+	metadata, err := SetProfile(accountData.PostingJSONMetadata, profile)
+	if err != nil {
+		return "", err
+	}
+	return h.UpdatePostingJsonMetadata(account, metadata, postingWif)
+}
+
+func parseJsonObject(s string) (map[string]json.RawMessage, error) {
+	obj := make(map[string]json.RawMessage)
+	if s == "" {
+		return obj, nil
+	}
+	if err := json.Unmarshal([]byte(s), &obj); err != nil {
+		return nil, err
+	}
+	if obj == nil {
+		// the metadata was a JSON null
+		obj = make(map[string]json.RawMessage)
+	}
+	return obj, nil
+}
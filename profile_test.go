@@ -0,0 +1,59 @@
+package hivego
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestProfileRoundTrip(t *testing.T) {
+	metadata := `{"profile":{"name":"Xeroc","about":"old","version":2,"profile_image":["a.png"]},"beneficiaries":[{"name":"x"}]}`
+
+	profile, err := ParseProfile(metadata)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if profile.Name != "Xeroc" || profile.About != "old" {
+		t.Error("Unexpected profile", profile)
+	}
+
+	profile.About = "new"
+	profile.Website = "https://hive.io"
+	updated, err := SetProfile(metadata, profile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got map[string]interface{}
+	json.Unmarshal([]byte(updated), &got)
+	if _, ok := got["beneficiaries"]; !ok {
+		t.Error("Expected unrelated metadata keys to be kept, got", updated)
+	}
+	gotProfile := got["profile"].(map[string]interface{})
+	if gotProfile["about"] != "new" || gotProfile["website"] != "https://hive.io" || gotProfile["name"] != "Xeroc" {
+		t.Error("Expected the edited profile, got", updated)
+	}
+	if gotProfile["version"] != float64(2) {
+		t.Error("Expected unknown profile fields to be kept, got", updated)
+	}
+	if _, ok := gotProfile["profile_image"].([]interface{}); !ok {
+		t.Error("Expected non string profile fields to be kept as is, got", updated)
+	}
+}
+
+func TestParseProfileEmpty(t *testing.T) {
+	for _, metadata := range []string{"", "{}", `{"profile":null}`} {
+		profile, err := ParseProfile(metadata)
+		if err != nil {
+			t.Error("Unexpected error for", metadata, err)
+		}
+		if profile.Name != "" {
+			t.Error("Expected an empty profile for", metadata)
+		}
+	}
+
+	updated, _ := SetProfile("", Profile{Name: "Xeroc"})
+	expected := `{"profile":{"name":"Xeroc"}}`
+	if updated != expected {
+		t.Error("Expected", expected, "got", updated)
+	}
+}
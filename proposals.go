@@ -0,0 +1,160 @@
+package hivego
+
+import (
+	"encoding/json"
+	"strconv"
+)
+
+type ProposalOrder string
+
+const (
+	ProposalsByCreator    ProposalOrder = "by_creator"
+	ProposalsByStartDate  ProposalOrder = "by_start_date"
+	ProposalsByEndDate    ProposalOrder = "by_end_date"
+	ProposalsByTotalVotes ProposalOrder = "by_total_votes"
+)
+
+type ProposalStatus string
+
+const (
+	ProposalStatusAll      ProposalStatus = "all"
+	ProposalStatusInactive ProposalStatus = "inactive"
+	ProposalStatusActive   ProposalStatus = "active"
+	ProposalStatusExpired  ProposalStatus = "expired"
+	ProposalStatusVotable  ProposalStatus = "votable"
+)
+
+type Proposal struct {
+	Id         int64
+	ProposalId int64
+	Creator    string
+	Receiver   string
+	StartDate  CustomTime
+	EndDate    CustomTime
+	DailyPay   Asset
+	Subject    string
+	Permlink   string
+	TotalVotes int64
+	Status     string
+}
+
+type listProposalsParams struct {
+	Start          []interface{}  `json:"start"`
+	Limit          int            `json:"limit"`
+	Order          ProposalOrder  `json:"order"`
+	OrderDirection string         `json:"order_direction"`
+	Status         ProposalStatus `json:"status"`
+}
+
+type findProposalsParams struct {
+	ProposalIds []int64 `json:"proposal_ids"`
+}
+
+// Large integers may come back from the node as strings
+type flexInt64 int64
+
+func (i *flexInt64) UnmarshalJSON(b []byte) error {
+	s := string(b)
+	if len(s) > 1 && s[0] == '"' {
+		s = s[1 : len(s)-1]
+	}
+	v, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return err
+	}
+	*i = flexInt64(v)
+	return nil
+}
+
+type apiProposal struct {
+	Id         int64      `json:"id"`
+	ProposalId int64      `json:"proposal_id"`
+	Creator    string     `json:"creator"`
+	Receiver   string     `json:"receiver"`
+	StartDate  CustomTime `json:"start_date"`
+	EndDate    CustomTime `json:"end_date"`
+	DailyPay   Asset      `json:"daily_pay"`
+	Subject    string     `json:"subject"`
+	Permlink   string     `json:"permlink"`
+	TotalVotes flexInt64  `json:"total_votes"`
+	Status     string     `json:"status"`
+}
+
+// Lists proposals in the given order starting from start, whose values depend
+// on the order: e.g. []interface{}{"creator"} for ProposalsByCreator or
+// []interface{}{"2024-01-01T00:00:00"} for ProposalsByStartDate
+func (h *HiveRpcNode) ListProposals(start []interface{}, limit int, order ProposalOrder, ascending bool, status ProposalStatus) ([]Proposal, error) {
+	direction := "descending"
+	if ascending {
+		direction = "ascending"
+	}
+	if start == nil {
+		start = []interface{}{}
+	}
+	query := hrpcQuery{
+		method: "database_api.list_proposals",
+		params: listProposalsParams{start, limit, order, direction, status},
+	}
+	return h.queryProposals(query)
+}
+
+// Returns all proposals of creator, whatever their status
+func (h *HiveRpcNode) GetProposalsByCreator(creator string) ([]Proposal, error) {
+	proposals, err := h.ListProposals([]interface{}{creator}, 1000, ProposalsByCreator, true, ProposalStatusAll)
+	if err != nil {
+		return nil, err
+	}
+
+	var owned []Proposal
+	for _, p := range proposals {
+		if p.Creator == creator {
+			owned = append(owned, p)
+		}
+	}
+	return owned, nil
+}
+
+// Fetches proposals by id. Removed proposals are not returned.
+func (h *HiveRpcNode) FindProposals(proposalIds []int64) ([]Proposal, error) {
+	query := hrpcQuery{
+		method: "database_api.find_proposals",
+		params: findProposalsParams{proposalIds},
+	}
+	return h.queryProposals(query)
+}
+
+func (h *HiveRpcNode) queryProposals(query hrpcQuery) ([]Proposal, error) {
+	res, err := h.rpcExec(query)
+	if err != nil {
+		return nil, err
+	}
+	return parseProposals(res)
+}
+
+func parseProposals(res []byte) ([]Proposal, error) {
+	var response struct {
+		Proposals []apiProposal `json:"proposals"`
+	}
+	err := json.Unmarshal(res, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	proposals := make([]Proposal, 0, len(response.Proposals))
+	for _, p := range response.Proposals {
+		proposals = append(proposals, Proposal{
+			Id:         p.Id,
+			ProposalId: p.ProposalId,
+			Creator:    p.Creator,
+			Receiver:   p.Receiver,
+			StartDate:  p.StartDate,
+			EndDate:    p.EndDate,
+			DailyPay:   p.DailyPay,
+			Subject:    p.Subject,
+			Permlink:   p.Permlink,
+			TotalVotes: int64(p.TotalVotes),
+			Status:     p.Status,
+		})
+	}
+	return proposals, nil
+}
@@ -0,0 +1,25 @@
+package hivego
+
+import "testing"
+
+func TestParseProposals(t *testing.T) {
+	res := []byte(`{"proposals":[{"id":0,"proposal_id":0,"creator":"hive.fund","receiver":"hive.fund","start_date":"2019-08-27T00:00:00","end_date":"2029-12-31T23:59:59","daily_pay":{"amount":"24000000000","precision":3,"nai":"@@000000013"},"subject":"Return Proposal","permlink":"return-proposal","total_votes":"57291374856787429","status":"active"}]}`)
+	proposals, err := parseProposals(res)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(proposals) != 1 {
+		t.Fatal("Expected 1 proposal, got", len(proposals))
+	}
+
+	p := proposals[0]
+	if p.DailyPay.String() != "24000000.000 HBD" {
+		t.Error("Expected daily pay 24000000.000 HBD, got", p.DailyPay)
+	}
+	if p.TotalVotes != 57291374856787429 {
+		t.Error("Expected total votes 57291374856787429, got", p.TotalVotes)
+	}
+	if p.EndDate.ToTime().Year() != 2029 {
+		t.Error("Expected end date in 2029, got", p.EndDate.ToTime())
+	}
+}
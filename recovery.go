@@ -0,0 +1,98 @@
+package hivego
+
+import (
+	"encoding/json"
+	"errors"
+)
+
+// Recovery of a compromised account. The recovery account first requests the
+// recovery with the new owner authority, then the account owner proves they
+// held a recent owner key by co-signing recover_account with the old and the
+// new owner keys within 24 hours.
+type AccountRecovery struct {
+	AccountToRecover string
+	RecoveryAccount  string
+	NewOwner         Authority
+}
+
+// Starts a recovery that replaces the owner authority with newOwnerKey
+func NewAccountRecovery(accountToRecover string, recoveryAccount string, newOwnerKey string) *AccountRecovery {
+	return &AccountRecovery{
+		AccountToRecover: accountToRecover,
+		RecoveryAccount:  recoveryAccount,
+		NewOwner:         NewKeyAuthority(newOwnerKey),
+	}
+}
+
+// The request, to be signed with the active key of the recovery account
+func (r *AccountRecovery) RequestOperation() RequestAccountRecoveryOperation {
+	return RequestAccountRecoveryOperation{r.RecoveryAccount, r.AccountToRecover, r.NewOwner, Extensions{}}
+}
+
+// The recovery itself. recentOwner must be an owner authority the account had
+// in the last 30 days.
+func (r *AccountRecovery) RecoverOperation(recentOwner Authority) RecoverAccountOperation {
+	return RecoverAccountOperation{r.AccountToRecover, r.NewOwner, recentOwner, Extensions{}}
+}
+
+// Broadcast the recovery request as the recovery account
+func (h *HiveRpcNode) RequestAccountRecovery(r *AccountRecovery, recoveryWif *string) (string, error) {
+	return h.Broadcast([]HiveOperation{r.RequestOperation()}, recoveryWif)
+}
+
+// Broadcast recover_account signed by both the old and the new owner keys.
+// The recent owner authority is taken to be the single old owner key, which is
+// the case for accounts created with the usual wallets.
+func (h *HiveRpcNode) RecoverAccount(r *AccountRecovery, oldOwnerWif *string, newOwnerWif *string) (string, error) {
+	oldOwner, err := KeyPairFromWif(*oldOwnerWif)
+	if err != nil {
+		return "", err
+	}
+	recentOwner := NewKeyAuthority(*oldOwner.GetPublicKeyString())
+
+	return h.RecoverAccountWithAuthority(r, recentOwner, []*string{oldOwnerWif}, []*string{newOwnerWif})
+}
+
+// Broadcast recover_account for any recent owner authority, signed with
+// enough keys to satisfy both the recent and the new owner authorities
+func (h *HiveRpcNode) RecoverAccountWithAuthority(r *AccountRecovery, recentOwner Authority, recentOwnerWifs []*string, newOwnerWifs []*string) (string, error) {
+	if len(recentOwnerWifs) == 0 || len(newOwnerWifs) == 0 {
+		return "", errors.New("recover_account must be signed by the recent and the new owner authorities")
+	}
+	wifs := append(append([]*string{}, recentOwnerWifs...), newOwnerWifs...)
+
+	return h.BroadcastMultiSig([]HiveOperation{r.RecoverOperation(recentOwner)}, wifs)
+}
+
+// A pending recovery request, valid until Expires
+type AccountRecoveryRequest struct {
+	Id                int64      `json:"id"`
+	AccountToRecover  string     `json:"account_to_recover"`
+	NewOwnerAuthority Authority  `json:"new_owner_authority"`
+	Expires           CustomTime `json:"expires"`
+}
+
+type findAccountRecoveryRequestsParams struct {
+	Accounts []string `json:"accounts"`
+}
+
+// Returns the pending recovery requests of the given accounts
+func (h *HiveRpcNode) GetAccountRecoveryRequests(accounts []string) ([]AccountRecoveryRequest, error) {
+	query := hrpcQuery{
+		method: "database_api.find_account_recovery_requests",
+		params: findAccountRecoveryRequestsParams{accounts},
+	}
+	res, err := h.rpcExec(query)
+	if err != nil {
+		return nil, err
+	}
+
+	var response struct {
+		Requests []AccountRecoveryRequest `json:"requests"`
+	}
+	err = json.Unmarshal(res, &response)
+	if err != nil {
+		return nil, err
+	}
+	return response.Requests, nil
+}
@@ -0,0 +1,87 @@
+package hivego
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// A recurrent transfer as stored on chain. TriggerDate is the date of the
+// next execution.
+type RecurrentTransfer struct {
+	Id                  int64
+	TriggerDate         CustomTime
+	From                string
+	To                  string
+	Amount              Asset
+	Memo                string
+	Recurrence          uint16
+	ConsecutiveFailures uint8
+	RemainingExecutions uint16
+	PairId              uint8
+}
+
+// Projects the dates of the remaining executions, assuming none fails
+func (r RecurrentTransfer) ExecutionDates() []time.Time {
+	dates := make([]time.Time, 0, r.RemainingExecutions)
+	next := r.TriggerDate.ToTime()
+	for i := 0; i < int(r.RemainingExecutions); i++ {
+		dates = append(dates, next)
+		next = next.Add(time.Duration(r.Recurrence) * time.Hour)
+	}
+	return dates
+}
+
+type findRecurrentTransfersParams struct {
+	From string `json:"from"`
+}
+
+// Returns the recurrent transfers sent by from
+func (h *HiveRpcNode) GetRecurrentTransfers(from string) ([]RecurrentTransfer, error) {
+	query := hrpcQuery{
+		method: "database_api.find_recurrent_transfers",
+		params: findRecurrentTransfersParams{from},
+	}
+	res, err := h.rpcExec(query)
+	if err != nil {
+		return nil, err
+	}
+	return parseRecurrentTransfers(res)
+}
+
+func parseRecurrentTransfers(res []byte) ([]RecurrentTransfer, error) {
+	var response struct {
+		RecurrentTransfers []struct {
+			Id                  int64      `json:"id"`
+			TriggerDate         CustomTime `json:"trigger_date"`
+			From                string     `json:"from"`
+			To                  string     `json:"to"`
+			Amount              Asset      `json:"amount"`
+			Memo                string     `json:"memo"`
+			Recurrence          uint16     `json:"recurrence"`
+			ConsecutiveFailures uint8      `json:"consecutive_failures"`
+			RemainingExecutions uint16     `json:"remaining_executions"`
+			PairId              uint8      `json:"pair_id"`
+		} `json:"recurrent_transfers"`
+	}
+	err := json.Unmarshal(res, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	transfers := make([]RecurrentTransfer, 0, len(response.RecurrentTransfers))
+	for _, r := range response.RecurrentTransfers {
+		transfers = append(transfers, RecurrentTransfer{
+			Id:                  r.Id,
+			TriggerDate:         r.TriggerDate,
+			From:                r.From,
+			To:                  r.To,
+			Amount:              r.Amount,
+			Memo:                r.Memo,
+			Recurrence:          r.Recurrence,
+			ConsecutiveFailures: r.ConsecutiveFailures,
+			RemainingExecutions: r.RemainingExecutions,
+			PairId:              r.PairId,
+		})
+	}
+	return transfers, nil
+}
@@ -0,0 +1,58 @@
+package hivego
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseRecurrentTransfers(t *testing.T) {
+	res := []byte(`{"recurrent_transfers":[{"id":3,"trigger_date":"2024-01-02T00:00:00","from":"alice","to":"bob","amount":{"amount":"5000","precision":3,"nai":"@@000000013"},"memo":"sub","recurrence":24,"consecutive_failures":0,"remaining_executions":3,"pair_id":1}]}`)
+	transfers, err := parseRecurrentTransfers(res)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(transfers) != 1 || transfers[0].Amount.String() != "5.000 HBD" || transfers[0].PairId != 1 {
+		t.Fatal("Unexpected transfers", transfers)
+	}
+
+	dates := transfers[0].ExecutionDates()
+	expected := []time.Time{
+		time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, 1, 4, 0, 0, 0, 0, time.UTC),
+	}
+	if len(dates) != len(expected) {
+		t.Fatal("Expected", expected, "got", dates)
+	}
+	for i := range dates {
+		if !dates[i].Equal(expected[i]) {
+			t.Error("Expected", expected[i], "got", dates[i])
+		}
+	}
+}
+
+func TestCheckRecurrentTransferSchedule(t *testing.T) {
+	cases := []struct {
+		recurrence uint16
+		executions uint16
+		valid      bool
+	}{
+		{24, 2, true},
+		{23, 2, false},
+		{24, 1, false},
+		{24, 729, true},
+		// 24 * 730 hours is exactly the 730 days allowed
+		{24, 730, true},
+		{24, 731, false},
+		{8760, 2, true},
+		// 17542 hours is still 730 whole days, 17544 is 731
+		{8771, 2, true},
+		{8772, 2, false},
+	}
+	for _, c := range cases {
+		err := checkRecurrentTransferSchedule(c.recurrence, c.executions)
+		if (err == nil) != c.valid {
+			t.Error(c.recurrence, c.executions, "expected valid", c.valid, "got", err)
+		}
+	}
+}
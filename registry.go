@@ -0,0 +1,129 @@
+package hivego
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// An operation of a type without a registered struct, as found in the block
+type GenericOperation struct {
+	Type  string
+	Value map[string]interface{}
+}
+
+func (o GenericOperation) OpName() string {
+	return strings.TrimSuffix(o.Type, "_operation")
+}
+
+func (o GenericOperation) SerializeOp() ([]byte, error) {
+	return nil, fmt.Errorf("%s can't be serialized, register its type first", o.Type)
+}
+
+var (
+	opRegistryMutex sync.RWMutex
+
+	// Returns a pointer to the struct each operation type is decoded into
+	opRegistry = map[string]func() HiveOperation{
+		OperationType.Vote:                        func() HiveOperation { return &VoteOperation{opText: "vote"} },
+		OperationType.Comment:                     func() HiveOperation { return &CommentOperation{} },
+		OperationType.Transfer:                    func() HiveOperation { return &TransferOperation{} },
+		OperationType.TransferToVesting:           func() HiveOperation { return &TransferToVestingOperation{} },
+		OperationType.WithdrawVesting:             func() HiveOperation { return &WithdrawVestingOperation{} },
+		OperationType.LimitOrderCreate:            func() HiveOperation { return &LimitOrderCreateOperation{} },
+		OperationType.LimitOrderCancel:            func() HiveOperation { return &LimitOrderCancelOperation{} },
+		OperationType.FeedPublish:                 func() HiveOperation { return &FeedPublishOperation{} },
+		OperationType.Convert:                     func() HiveOperation { return &ConvertOperation{} },
+		OperationType.AccountCreate:               func() HiveOperation { return &AccountCreateOperation{} },
+		OperationType.AccountUpdate:               func() HiveOperation { return &AccountUpdateOperation{opText: "account_update"} },
+		OperationType.WitnessUpdate:               func() HiveOperation { return &WitnessUpdateOperation{} },
+		OperationType.AccountWitnessVote:          func() HiveOperation { return &AccountWitnessVoteOperation{} },
+		OperationType.AccountWitnessProxy:         func() HiveOperation { return &AccountWitnessProxyOperation{} },
+		OperationType.Custom:                      func() HiveOperation { return &CustomOperation{} },
+		OperationType.DeleteComment:               func() HiveOperation { return &DeleteCommentOperation{} },
+		OperationType.CustomJson:                  func() HiveOperation { return &CustomJsonOperation{opText: "custom_json"} },
+		OperationType.CommentOptions:              func() HiveOperation { return &CommentOptionsOperation{} },
+		OperationType.SetWithdrawVestingRoute:     func() HiveOperation { return &SetWithdrawVestingRouteOperation{} },
+		OperationType.LimitOrderCreate2:           func() HiveOperation { return &LimitOrderCreate2Operation{} },
+		OperationType.ClaimAccount:                func() HiveOperation { return &ClaimAccountOperation{} },
+		OperationType.CreateClaimedAccount:        func() HiveOperation { return &CreateClaimedAccountOperation{} },
+		OperationType.RequestAccountRecovery:      func() HiveOperation { return &RequestAccountRecoveryOperation{} },
+		OperationType.RecoverAccount:              func() HiveOperation { return &RecoverAccountOperation{} },
+		OperationType.ChangeRecoveryAccount:       func() HiveOperation { return &ChangeRecoveryAccountOperation{} },
+		OperationType.EscrowTransfer:              func() HiveOperation { return &EscrowTransferOperation{} },
+		OperationType.EscrowDispute:               func() HiveOperation { return &EscrowDisputeOperation{} },
+		OperationType.EscrowRelease:               func() HiveOperation { return &EscrowReleaseOperation{} },
+		OperationType.EscrowApprove:               func() HiveOperation { return &EscrowApproveOperation{} },
+		OperationType.TransferToSavings:           func() HiveOperation { return &TransferToSavings{} },
+		OperationType.TransferFromSavings:         func() HiveOperation { return &TransferFromSavings{} },
+		OperationType.CancelTransferFromSavings:   func() HiveOperation { return &CancelTransferFromSavings{} },
+		OperationType.CustomBinary:                func() HiveOperation { return &CustomBinaryOperation{} },
+		OperationType.ClaimRewardBalance:          func() HiveOperation { return &ClaimRewardOperation{opText: "claim_reward_balance"} },
+		OperationType.DelegateVestingShares:       func() HiveOperation { return &DelegateVestingSharesOperation{} },
+		OperationType.AccountCreateWithDelegation: func() HiveOperation { return &AccountCreateWithDelegationOperation{} },
+		OperationType.WitnessSetProperties:        func() HiveOperation { return &WitnessSetPropertiesOperation{} },
+		OperationType.AccountUpdate2:              func() HiveOperation { return &AccountUpdate2Operation{} },
+		OperationType.CreateProposal:              func() HiveOperation { return &CreateProposalOperation{} },
+		OperationType.UpdateProposalVotes:         func() HiveOperation { return &UpdateProposalVotesOperation{} },
+		OperationType.RemoveProposal:              func() HiveOperation { return &RemoveProposalOperation{} },
+		OperationType.UpdateProposal:              func() HiveOperation { return &UpdateProposalOperation{} },
+		OperationType.CollateralizedConvert:       func() HiveOperation { return &CollateralizedConvertOperation{} },
+		OperationType.RecurrentTransfer:           func() HiveOperation { return &RecurrentTransferOperation{} },
+	}
+)
+
+// Registers the struct an operation type such as "transfer_operation" is
+// decoded into, replacing any previous registration. newOp must return a
+// pointer to a new value every time. Decode returns the value the pointer
+// points to, or the pointer itself if only the pointer implements
+// HiveOperation.
+func RegisterOperation(opType string, newOp func() HiveOperation) error {
+	if newOp == nil {
+		return errors.New("newOp must not be nil")
+	}
+	if v := reflect.ValueOf(newOp()); v.Kind() != reflect.Ptr || v.IsNil() {
+		return fmt.Errorf("newOp of %s must return a non-nil pointer", opType)
+	}
+
+	opRegistryMutex.Lock()
+	defer opRegistryMutex.Unlock()
+	opRegistry[opType] = newOp
+	return nil
+}
+
+// Decodes the operation into its registered struct, e.g. TransferOperation for
+// a transfer_operation. Types without a registered struct are returned as a
+// GenericOperation.
+func (o Operation) Decode() (HiveOperation, error) {
+	opRegistryMutex.RLock()
+	newOp, ok := opRegistry[o.Type]
+	opRegistryMutex.RUnlock()
+	if !ok {
+		return GenericOperation{o.Type, o.Value}, nil
+	}
+
+	ptr := newOp()
+	if err := decodeOperationValue(o, o.Type, ptr); err != nil {
+		return nil, fmt.Errorf("decoding %s: %w", o.Type, err)
+	}
+	// registered as pointers, returned as values like the operations hivego builds
+	if op, ok := reflect.ValueOf(ptr).Elem().Interface().(HiveOperation); ok {
+		return op, nil
+	}
+	return ptr, nil
+}
+
+// Decodes every operation of the transaction
+func (t Transaction) DecodeOperations() ([]HiveOperation, error) {
+	ops := make([]HiveOperation, 0, len(t.Operations))
+	for _, op := range t.Operations {
+		decoded, err := op.Decode()
+		if err != nil {
+			return nil, err
+		}
+		ops = append(ops, decoded)
+	}
+	return ops, nil
+}
@@ -0,0 +1,113 @@
+package hivego
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func decodeTestOperation(t *testing.T, raw string) HiveOperation {
+	var op Operation
+	if err := json.Unmarshal([]byte(raw), &op); err != nil {
+		t.Fatal(err)
+	}
+	decoded, err := op.Decode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return decoded
+}
+
+func TestOperationDecode(t *testing.T) {
+	transfer := decodeTestOperation(t, `{"type":"transfer_operation","value":{"from":"tibfox.vsc","to":"vsc.gateway","amount":{"amount":"1000","precision":3,"nai":"@@000000021"},"memo":"to=tibfox"}}`)
+	if !reflect.DeepEqual(transfer, getTestTransferOp()) {
+		t.Error("Expected", getTestTransferOp(), "got", transfer)
+	}
+
+	vote := decodeTestOperation(t, `{"type":"vote_operation","value":{"voter":"xeroc","author":"xeroc","permlink":"piston","weight":10000}}`)
+	if !reflect.DeepEqual(vote, getTestVoteOp()) {
+		t.Error("Expected", getTestVoteOp(), "got", vote)
+	}
+
+	order := decodeTestOperation(t, `{"type":"limit_order_create2_operation","value":{"owner":"xeroc","orderid":7,"amount_to_sell":{"amount":"1000","precision":3,"nai":"@@000000021"},"exchange_rate":{"base":{"amount":"1000","precision":3,"nai":"@@000000021"},"quote":{"amount":"300","precision":3,"nai":"@@000000013"}},"fill_or_kill":false,"expiration":"2016-08-08T12:24:17"}}`)
+	expectedOrder := LimitOrderCreate2Operation{"xeroc", 7, MustParseAsset("1.000 HIVE"), Price{MustParseAsset("1.000 HIVE"), MustParseAsset("0.300 HBD")}, false, getTestExpiration()}
+	if !reflect.DeepEqual(order, expectedOrder) {
+		t.Error("Expected", expectedOrder, "got", order)
+	}
+}
+
+func TestOperationDecodeExtensions(t *testing.T) {
+	options := decodeTestOperation(t, `{"type":"comment_options_operation","value":{"author":"xeroc","permlink":"piston","max_accepted_payout":{"amount":"1000000000","precision":3,"nai":"@@000000013"},"percent_hbd":10000,"allow_votes":true,"allow_curation_rewards":true,"extensions":[{"type":"comment_payout_beneficiaries","value":{"beneficiaries":[{"account":"bob","weight":500}]}}]}}`)
+	expected := CommentOptionsOperation{"xeroc", "piston", MustParseAsset("1000000.000 HBD"), 10000, true, true,
+		Extensions{CommentPayoutBeneficiaries{[]Beneficiary{{"bob", 500}}}}}
+	if !reflect.DeepEqual(options, expected) {
+		t.Error("Expected", expected, "got", options)
+	}
+
+	var exts Extensions
+	if err := json.Unmarshal([]byte(`[[0,{"pair_id":3}]]`), &exts); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(exts, Extensions{RecurrentTransferPairId{3}}) {
+		t.Error("Unexpected extensions", exts)
+	}
+}
+
+func TestOperationDecodeWitnessProps(t *testing.T) {
+	op := decodeTestOperation(t, `{"type":"witness_set_properties_operation","value":{"owner":"xeroc","props":[["key","`+hex.EncodeToString(testPubKeyBytes)+`"],["maximum_block_size","00000100"]],"extensions":[]}}`)
+	props := op.(WitnessSetPropertiesOperation).Props
+	if _, err := op.SerializeOp(); err != nil || len(props.keys()) != 2 {
+		t.Error("Unexpected witness props", props.keys(), err)
+	}
+}
+
+func TestOperationDecodeGeneric(t *testing.T) {
+	op := decodeTestOperation(t, `{"type":"pow2_operation","value":{"work":{}}}`)
+	generic, ok := op.(GenericOperation)
+	if !ok || generic.OpName() != "pow2" {
+		t.Fatal("Expected a generic pow2 operation, got", op)
+	}
+	if _, err := generic.SerializeOp(); err == nil {
+		t.Error("Expected an error serializing a generic operation")
+	}
+}
+
+type testPluginOperation struct {
+	Account string `json:"account"`
+}
+
+func (o testPluginOperation) OpName() string               { return "test_plugin" }
+func (o testPluginOperation) SerializeOp() ([]byte, error) { return nil, nil }
+
+type testPointerOperation struct {
+	Account string `json:"account"`
+}
+
+func (o *testPointerOperation) OpName() string               { return "test_pointer" }
+func (o *testPointerOperation) SerializeOp() ([]byte, error) { return nil, nil }
+
+func TestRegisterOperation(t *testing.T) {
+	if err := RegisterOperation("test_plugin_operation", func() HiveOperation { return testPluginOperation{} }); err == nil {
+		t.Error("Expected an error registering a non-pointer type")
+	}
+	err := RegisterOperation("test_plugin_operation", func() HiveOperation { return &testPluginOperation{} })
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	op := decodeTestOperation(t, `{"type":"test_plugin_operation","value":{"account":"xeroc"}}`)
+	if op != (testPluginOperation{"xeroc"}) {
+		t.Error("Unexpected operation", op)
+	}
+
+	// only the pointer implements HiveOperation, so the pointer is returned
+	err = RegisterOperation("test_pointer_operation", func() HiveOperation { return &testPointerOperation{} })
+	if err != nil {
+		t.Fatal(err)
+	}
+	op = decodeTestOperation(t, `{"type":"test_pointer_operation","value":{"account":"xeroc"}}`)
+	if ptr, ok := op.(*testPointerOperation); !ok || ptr.Account != "xeroc" {
+		t.Error("Unexpected operation", op)
+	}
+}
@@ -0,0 +1,199 @@
+package hivego
+
+import (
+	"fmt"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v2"
+)
+
+// An authority an operation needs signatures for: the authority of Account
+// at Level, or for the few operations that name one directly, Authority
+type RequiredAuthority struct {
+	Account   string
+	Level     AuthorityLevel
+	Authority *Authority
+}
+
+// Implemented by operations registered outside hivego to report the
+// authorities they need
+type AuthorityRequirer interface {
+	RequiredAuthorities() ([]RequiredAuthority, error)
+}
+
+// Returns the authorities op needs to be signed with, in the order the
+// accounts appear in the operation
+func RequiredAuthorities(op HiveOperation) ([]RequiredAuthority, error) {
+	if requirer, ok := op.(AuthorityRequirer); ok {
+		return requirer.RequiredAuthorities()
+	}
+
+	posting := func(accounts ...string) []RequiredAuthority {
+		return accountAuthorities(AuthorityPosting, accounts...)
+	}
+	active := func(accounts ...string) []RequiredAuthority {
+		return accountAuthorities(AuthorityActive, accounts...)
+	}
+	owner := func(accounts ...string) []RequiredAuthority {
+		return accountAuthorities(AuthorityOwner, accounts...)
+	}
+
+	switch o := op.(type) {
+	case VoteOperation:
+		return posting(o.Voter), nil
+	case CommentOperation:
+		return posting(o.Author), nil
+	case CommentOptionsOperation:
+		return posting(o.Author), nil
+	case DeleteCommentOperation:
+		return posting(o.Author), nil
+	case ClaimRewardOperation:
+		return posting(o.Account), nil
+	case TransferOperation:
+		return active(o.From), nil
+	case RecurrentTransferOperation:
+		return active(o.From), nil
+	case TransferToVestingOperation:
+		return active(o.From), nil
+	case WithdrawVestingOperation:
+		return active(o.Account), nil
+	case SetWithdrawVestingRouteOperation:
+		return active(o.FromAccount), nil
+	case DelegateVestingSharesOperation:
+		return active(o.Delegator), nil
+	case TransferToSavings:
+		return active(o.From), nil
+	case TransferFromSavings:
+		return active(o.From), nil
+	case CancelTransferFromSavings:
+		return active(o.From), nil
+	case LimitOrderCreateOperation:
+		return active(o.Owner), nil
+	case LimitOrderCreate2Operation:
+		return active(o.Owner), nil
+	case LimitOrderCancelOperation:
+		return active(o.Owner), nil
+	case ConvertOperation:
+		return active(o.Owner), nil
+	case CollateralizedConvertOperation:
+		return active(o.Owner), nil
+	case FeedPublishOperation:
+		return active(o.Publisher), nil
+	case EscrowTransferOperation:
+		return active(o.From), nil
+	case EscrowApproveOperation:
+		return active(o.Who), nil
+	case EscrowDisputeOperation:
+		return active(o.Who), nil
+	case EscrowReleaseOperation:
+		return active(o.Who), nil
+	case WitnessUpdateOperation:
+		return active(o.Owner), nil
+	case AccountWitnessVoteOperation:
+		return active(o.Account), nil
+	case AccountWitnessProxyOperation:
+		return active(o.Account), nil
+	case CreateProposalOperation:
+		return active(o.Creator), nil
+	case UpdateProposalOperation:
+		return active(o.Creator), nil
+	case UpdateProposalVotesOperation:
+		return active(o.Voter), nil
+	case RemoveProposalOperation:
+		return active(o.ProposalOwner), nil
+	case AccountCreateOperation:
+		return active(o.Creator), nil
+	case AccountCreateWithDelegationOperation:
+		return active(o.Creator), nil
+	case ClaimAccountOperation:
+		return active(o.Creator), nil
+	case CreateClaimedAccountOperation:
+		return active(o.Creator), nil
+	case RequestAccountRecoveryOperation:
+		return active(o.RecoveryAccount), nil
+	case ChangeRecoveryAccountOperation:
+		return owner(o.AccountToRecover), nil
+	case RecoverAccountOperation:
+		return []RequiredAuthority{{Authority: &o.NewOwnerAuthority}, {Authority: &o.RecentOwnerAuthority}}, nil
+	case AccountUpdateOperation:
+		if o.Owner != nil {
+			return owner(o.Account), nil
+		}
+		return active(o.Account), nil
+	case AccountUpdate2Operation:
+		switch {
+		case o.Owner != nil:
+			return owner(o.Account), nil
+		case o.Active != nil || o.Posting != nil || o.MemoKey != "" || o.JsonMetadata != "":
+			return active(o.Account), nil
+		}
+		return posting(o.Account), nil
+	case CustomOperation:
+		return active(o.RequiredAuths...), nil
+	case CustomJsonOperation:
+		return append(active(o.RequiredAuths...), posting(o.RequiredPostingAuths...)...), nil
+	case CustomBinaryOperation:
+		required := append(owner(o.RequiredOwnerAuths...), active(o.RequiredActiveAuths...)...)
+		required = append(required, posting(o.RequiredPostingAuths...)...)
+		for i := range o.RequiredAuths {
+			required = append(required, RequiredAuthority{Authority: &o.RequiredAuths[i]})
+		}
+		return required, nil
+	case WitnessSetPropertiesOperation:
+		// signed with the block signing key rather than an account authority
+		key, err := secp256k1.ParsePubKey(o.Props.props["key"])
+		if err != nil {
+			return nil, fmt.Errorf("witness_set_properties needs the signing key: %w", err)
+		}
+		auth := NewKeyAuthority(*GetPublicKeyString(key))
+		return []RequiredAuthority{{Authority: &auth}}, nil
+	}
+	return nil, fmt.Errorf("required authorities of %s are unknown", op.OpName())
+}
+
+// Returns the authorities the operations of tx need. An account needing
+// both active and owner is only listed with owner, whose keys also satisfy
+// active. The chain rejects transactions mixing posting authorities with any
+// other, which is an error here too.
+func (t *HiveTransaction) RequiredAuthorities() ([]RequiredAuthority, error) {
+	rank := map[AuthorityLevel]int{AuthorityPosting: 0, AuthorityActive: 1, AuthorityOwner: 2}
+
+	var required []RequiredAuthority
+	index := map[string]int{}
+	posting, other := false, false
+	for _, op := range t.Operations {
+		opRequired, err := RequiredAuthorities(op)
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range opRequired {
+			if r.Authority == nil && r.Level == AuthorityPosting {
+				posting = true
+			} else {
+				other = true
+			}
+			if posting && other {
+				return nil, fmt.Errorf("%s mixes posting authorities with active or owner ones, which the chain rejects", op.OpName())
+			}
+			if r.Authority != nil {
+				required = append(required, r)
+				continue
+			}
+			i, ok := index[r.Account]
+			if !ok {
+				index[r.Account] = len(required)
+				required = append(required, r)
+			} else if rank[r.Level] > rank[required[i].Level] {
+				required[i].Level = r.Level
+			}
+		}
+	}
+	return required, nil
+}
+
+func accountAuthorities(level AuthorityLevel, accounts ...string) []RequiredAuthority {
+	required := make([]RequiredAuthority, 0, len(accounts))
+	for _, account := range accounts {
+		required = append(required, RequiredAuthority{Account: account, Level: level})
+	}
+	return required
+}
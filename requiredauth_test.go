@@ -0,0 +1,95 @@
+package hivego
+
+import (
+	"reflect"
+	"testing"
+)
+
+func (o testPluginOperation) RequiredAuthorities() ([]RequiredAuthority, error) {
+	return []RequiredAuthority{{Account: "plugin", Level: AuthorityActive}}, nil
+}
+
+func TestRequiredAuthorities(t *testing.T) {
+	owner := NewKeyAuthority(testPubKey)
+	cases := []struct {
+		op       HiveOperation
+		expected []RequiredAuthority
+	}{
+		{getTestVoteOp(), []RequiredAuthority{{Account: "xeroc", Level: AuthorityPosting}}},
+		{getTestTransferOp(), []RequiredAuthority{{Account: "tibfox.vsc", Level: AuthorityActive}}},
+		{getTestAccountUpdateOp(), []RequiredAuthority{{Account: "sniperduel17", Level: AuthorityActive}}},
+		{AccountUpdateOperation{Account: "alice", Owner: &owner}, []RequiredAuthority{{Account: "alice", Level: AuthorityOwner}}},
+		{AccountUpdate2Operation{Account: "alice", PostingJsonMetadata: "{}"}, []RequiredAuthority{{Account: "alice", Level: AuthorityPosting}}},
+		{AccountUpdate2Operation{Account: "alice", JsonMetadata: "{}"}, []RequiredAuthority{{Account: "alice", Level: AuthorityActive}}},
+		{AccountUpdate2Operation{Account: "alice", Posting: &owner}, []RequiredAuthority{{Account: "alice", Level: AuthorityActive}}},
+		{CustomJsonOperation{RequiredAuths: []string{"alice"}, RequiredPostingAuths: []string{"bob"}}, []RequiredAuthority{
+			{Account: "alice", Level: AuthorityActive},
+			{Account: "bob", Level: AuthorityPosting},
+		}},
+		{RecoverAccountOperation{AccountToRecover: "alice", NewOwnerAuthority: owner, RecentOwnerAuthority: owner}, []RequiredAuthority{
+			{Authority: &owner},
+			{Authority: &owner},
+		}},
+		{WitnessSetPropertiesOperation{Owner: "alice", Props: *NewWitnessProps(testPubKey)}, []RequiredAuthority{{Authority: &owner}}},
+		{testPluginOperation{}, []RequiredAuthority{{Account: "plugin", Level: AuthorityActive}}},
+	}
+	for _, c := range cases {
+		got, err := RequiredAuthorities(c.op)
+		if err != nil {
+			t.Error(c.op.OpName(), err)
+			continue
+		}
+		if !reflect.DeepEqual(got, c.expected) {
+			t.Error(c.op.OpName(), "expected", c.expected, "got", got)
+		}
+	}
+
+	// every operation hivego can build has known authorities
+	for _, op := range getTestDeserializeOps() {
+		if _, err := RequiredAuthorities(op); err != nil {
+			t.Error(err)
+		}
+	}
+	if _, err := RequiredAuthorities(GenericOperation{Type: "decline_voting_rights_operation"}); err == nil {
+		t.Error("Expected an error for an unknown operation")
+	}
+}
+
+func TestTransactionRequiredAuthorities(t *testing.T) {
+	tx := getTestTx([]HiveOperation{
+		TransferOperation{From: "xeroc", To: "alice", Amount: MustParseAsset("1.000 HIVE")},
+		CustomJsonOperation{RequiredAuths: []string{"alice"}},
+		ChangeRecoveryAccountOperation{AccountToRecover: "alice", NewRecoveryAccount: "bob"},
+	})
+	got, err := tx.RequiredAuthorities()
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []RequiredAuthority{{Account: "xeroc", Level: AuthorityActive}, {Account: "alice", Level: AuthorityOwner}}
+	if !reflect.DeepEqual(got, expected) {
+		t.Error("Expected", expected, "got", got)
+	}
+
+	tx = getTestTx([]HiveOperation{getTestVoteOp(), CustomJsonOperation{RequiredPostingAuths: []string{"alice", "xeroc"}}})
+	got, err = tx.RequiredAuthorities()
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected = []RequiredAuthority{{Account: "xeroc", Level: AuthorityPosting}, {Account: "alice", Level: AuthorityPosting}}
+	if !reflect.DeepEqual(got, expected) {
+		t.Error("Expected", expected, "got", got)
+	}
+
+	// hived's tx_combined_auths_with_posting
+	mixed := [][]HiveOperation{
+		{getTestVoteOp(), TransferOperation{From: "xeroc", To: "alice", Amount: MustParseAsset("1.000 HIVE")}},
+		{CustomJsonOperation{RequiredAuths: []string{"alice"}, RequiredPostingAuths: []string{"bob"}}},
+		{ChangeRecoveryAccountOperation{AccountToRecover: "alice", NewRecoveryAccount: "bob"}, getTestVoteOp()},
+	}
+	for _, ops := range mixed {
+		tx := getTestTx(ops)
+		if _, err := tx.RequiredAuthorities(); err == nil {
+			t.Error("Expected an error mixing posting and active or owner authorities in", ops)
+		}
+	}
+}
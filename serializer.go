@@ -6,10 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
-	"log"
 	"sort"
-	"strconv"
-	"strings"
 	"time"
 )
 
@@ -62,75 +59,129 @@ func appendVString(s string, b *bytes.Buffer) *bytes.Buffer {
 }
 
 func appendVStringArray(a []string, b *bytes.Buffer) *bytes.Buffer {
-	b.Write([]byte{byte(len(a))})
+	WriteUvarint(b, uint64(len(a)))
 	for _, s := range a {
 		appendVString(s, b)
 	}
 	return b
 }
 
-func appendVAsset(asset string, b *bytes.Buffer) error {
-	parts := strings.Split(asset, " ")
-	if len(parts) != 2 {
-		return errors.New("invalid asset format: " + asset)
+// Writes account names the way the chain stores a flat_set: sorted by name
+// and without duplicates, whatever order the caller listed them in
+func appendAccountSet(accounts []string, b *bytes.Buffer) *bytes.Buffer {
+	sorted := append([]string{}, accounts...)
+	sort.Strings(sorted)
+	set := make([]string, 0, len(sorted))
+	for _, account := range sorted {
+		if len(set) == 0 || account != set[len(set)-1] {
+			set = append(set, account)
+		}
 	}
+	return appendVStringArray(set, b)
+}
 
-	amountStr, symbol := parts[0], parts[1]
-	precision := 3
-	if symbol == "VESTS" {
-		precision = 6
+func appendBool(v bool, b *bytes.Buffer) {
+	if v {
+		b.WriteByte(1)
+	} else {
+		b.WriteByte(0)
 	}
+}
+
+func appendUint16(v uint16, b *bytes.Buffer) {
+	buf := make([]byte, 2)
+	binary.LittleEndian.PutUint16(buf, v)
+	b.Write(buf)
+}
+
+func appendUint32(v uint32, b *bytes.Buffer) {
+	buf := make([]byte, 4)
+	binary.LittleEndian.PutUint32(buf, v)
+	b.Write(buf)
+}
+
+func appendTime(t CustomTime, b *bytes.Buffer) {
+	appendUint32(uint32(time.Time(t).Unix()), b)
+}
 
-	var nai uint32
-	switch symbol {
-	case "HIVE":
-		fallthrough
-	case "TESTS":
-		nai = ((99999999 + 2) << 5) | 3
-	case "HBD":
-		fallthrough
-	case "TBD":
-		nai = ((99999999 + 1) << 5) | 3
-	case "VESTS":
-		nai = ((99999999 + 3) << 5) | 6
-	default:
-		return errors.New("invalid asset symbol")
+func appendPrice(p Price, b *bytes.Buffer) error {
+	err := appendAsset(p.Base, b)
+	if err != nil {
+		return err
 	}
+	return appendAsset(p.Quote, b)
+}
 
-	// Handle decimal parsing without floating points
-	parts = strings.Split(amountStr, ".")
-	if len(parts) > 2 {
-		return errors.New("invalid amount format: " + amountStr)
+// Writes a public key as 33 compressed bytes. The null key is accepted and
+// written as zeros.
+func appendPublicKey(key string, b *bytes.Buffer) error {
+	if key == NullPublicKey {
+		b.Write(make([]byte, 33))
+		return nil
+	}
+	pubKey, err := DecodePublicKey(key)
+	if err != nil {
+		return err
 	}
+	b.Write(pubKey.SerializeCompressed())
+	return nil
+}
 
-	// Pad or truncate decimal part to required precision
-	decimalPart := ""
-	if len(parts) > 1 {
-		decimalPart = parts[1]
+// Writes a byte array as a varint length followed by the bytes
+func appendVBytes(data []byte, b *bytes.Buffer) {
+	WriteUvarint(b, uint64(len(data)))
+	b.Write(data)
+}
+
+// Writes a flat_set<int64> of proposal ids, sorted and without duplicates
+func appendProposalIds(ids []int64, b *bytes.Buffer) error {
+	ids = sortedProposalIds(ids)
+	if err := WriteUvarint(b, uint64(len(ids))); err != nil {
+		return err
 	}
-	if len(decimalPart) > precision {
-		decimalPart = decimalPart[:precision]
-	} else {
-		decimalPart += strings.Repeat("0", precision-len(decimalPart))
+	for _, id := range ids {
+		if err := binary.Write(b, binary.LittleEndian, id); err != nil {
+			return err
+		}
 	}
+	return nil
+}
 
-	// Combine whole and decimal parts
-	fullNumber := parts[0] + decimalPart
-	amount, err := strconv.ParseInt(fullNumber, 10, 64)
-	if err != nil {
+// Writes the extensions as a varint length followed by each static_variant:
+// the varint type index and the serialized extension
+func appendExtensions(exts Extensions, b *bytes.Buffer) error {
+	if err := WriteUvarint(b, uint64(len(exts))); err != nil {
 		return err
 	}
+	for _, ext := range exts {
+		if err := WriteUvarint(b, ext.ExtensionId()); err != nil {
+			return err
+		}
+		extB, err := ext.SerializeExt()
+		if err != nil {
+			return err
+		}
+		b.Write(extB)
+	}
+	return nil
+}
+
+// Writes the amount as int64 followed by the nai number with the precision
+// in its low bits
+func appendAsset(asset Asset, b *bytes.Buffer) error {
+	info, ok := assets[asset.Symbol]
+	if !ok {
+		return fmt.Errorf("invalid asset symbol %q", asset.Symbol)
+	}
+	if asset.Precision != info.precision {
+		return fmt.Errorf("%s must have precision %d", asset.Symbol, info.precision)
+	}
 
-	// Write the amount as int64
-	err = binary.Write(b, binary.LittleEndian, amount)
+	err := binary.Write(b, binary.LittleEndian, asset.Amount)
 	if err != nil {
 		return err
 	}
-
-	// Write the nai
-	naiBytes := make([]byte, 4)
-	binary.LittleEndian.PutUint32(naiBytes, nai)
-	b.Write(naiBytes)
+	appendUint32(info.naiNum<<5|uint32(asset.Precision), b)
 
 	return nil
 }
@@ -167,7 +218,7 @@ func serializeOps(ops []HiveOperation) ([]byte, error) {
 	return opsBuf.Bytes(), nil
 }
 
-func (o voteOperation) SerializeOp() ([]byte, error) {
+func (o VoteOperation) SerializeOp() ([]byte, error) {
 	var voteBuf bytes.Buffer
 	voteBuf.Write([]byte{opIdB(o.OpName())})
 	appendVString(o.Voter, &voteBuf)
@@ -181,34 +232,126 @@ func (o voteOperation) SerializeOp() ([]byte, error) {
 	return voteBuf.Bytes(), nil
 }
 
+func (o CommentOperation) SerializeOp() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte(opIdB(o.OpName()))
+	appendVString(o.ParentAuthor, &buf)
+	appendVString(o.ParentPermlink, &buf)
+	appendVString(o.Author, &buf)
+	appendVString(o.Permlink, &buf)
+	appendVString(o.Title, &buf)
+	appendVString(o.Body, &buf)
+	appendVString(o.JsonMetadata, &buf)
+
+	return buf.Bytes(), nil
+}
+
+func (o DeleteCommentOperation) SerializeOp() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte(opIdB(o.OpName()))
+	appendVString(o.Author, &buf)
+	appendVString(o.Permlink, &buf)
+
+	return buf.Bytes(), nil
+}
+
+func (e CommentPayoutBeneficiaries) SerializeExt() ([]byte, error) {
+	sorted, err := e.sorted()
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	if err := WriteUvarint(&buf, uint64(len(sorted))); err != nil {
+		return nil, err
+	}
+	for _, b := range sorted {
+		appendVString(b.Account, &buf)
+		appendUint16(b.Weight, &buf)
+	}
+
+	return buf.Bytes(), nil
+}
+
+func (o CommentOptionsOperation) SerializeOp() ([]byte, error) {
+	if o.PercentHbd > 10000 {
+		return nil, errors.New("percent_hbd must be at most 10000")
+	}
+
+	var buf bytes.Buffer
+	buf.WriteByte(opIdB(o.OpName()))
+	appendVString(o.Author, &buf)
+	appendVString(o.Permlink, &buf)
+	err := appendAsset(o.MaxAcceptedPayout, &buf)
+	if err != nil {
+		return nil, err
+	}
+	appendUint16(o.PercentHbd, &buf)
+	appendBool(o.AllowVotes, &buf)
+	appendBool(o.AllowCurationRewards, &buf)
+	err = appendExtensions(o.Extensions, &buf)
+	if err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
 func (o CustomJsonOperation) SerializeOp() ([]byte, error) {
 	var jBuf bytes.Buffer
 	jBuf.Write([]byte{opIdB(o.OpName())})
-	appendVStringArray(o.RequiredAuths, &jBuf)
-	appendVStringArray(o.RequiredPostingAuths, &jBuf)
+	appendAccountSet(o.RequiredAuths, &jBuf)
+	appendAccountSet(o.RequiredPostingAuths, &jBuf)
 	appendVString(o.Id, &jBuf)
 	appendVString(o.Json, &jBuf)
 
 	return jBuf.Bytes(), nil
 }
 
+func (o CustomOperation) SerializeOp() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte(opIdB(o.OpName()))
+	appendAccountSet(o.RequiredAuths, &buf)
+	appendUint16(o.Id, &buf)
+	appendVBytes(o.Data, &buf)
+
+	return buf.Bytes(), nil
+}
+
+func (o CustomBinaryOperation) SerializeOp() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte(opIdB(o.OpName()))
+	appendAccountSet(o.RequiredOwnerAuths, &buf)
+	appendAccountSet(o.RequiredActiveAuths, &buf)
+	appendAccountSet(o.RequiredPostingAuths, &buf)
+	WriteUvarint(&buf, uint64(len(o.RequiredAuths)))
+	err := serializeAuthorities(&buf, o.RequiredAuths...)
+	if err != nil {
+		return nil, err
+	}
+	appendVString(o.Id, &buf)
+	appendVBytes(o.Data, &buf)
+
+	return buf.Bytes(), nil
+}
+
 func (o ClaimRewardOperation) SerializeOp() ([]byte, error) {
 	var claimBuf bytes.Buffer
 	claimBuf.Write([]byte{opIdB(o.OpName())})
 	appendVString(o.Account, &claimBuf)
-	err := appendVAsset(o.RewardHIVE, &claimBuf)
+	err := appendAsset(o.RewardHIVE, &claimBuf)
 
 	if err != nil {
 		return nil, err
 	}
 
-	err = appendVAsset(o.RewardHBD, &claimBuf)
+	err = appendAsset(o.RewardHBD, &claimBuf)
 
 	if err != nil {
 		return nil, err
 	}
 
-	err = appendVAsset(o.RewardVests, &claimBuf)
+	err = appendAsset(o.RewardVests, &claimBuf)
 
 	if err != nil {
 		return nil, err
@@ -222,7 +365,10 @@ func (o TransferOperation) SerializeOp() ([]byte, error) {
 	transferBuf.Write([]byte{opIdB(o.OpName())})
 	appendVString(o.From, &transferBuf)
 	appendVString(o.To, &transferBuf)
-	appendVAsset(o.Amount, &transferBuf)
+	err := appendAsset(o.Amount, &transferBuf)
+	if err != nil {
+		return nil, err
+	}
 	appendVString(o.Memo, &transferBuf)
 
 	return transferBuf.Bytes(), nil
@@ -238,10 +384,10 @@ func (a AccountUpdateOperation) SerializeOp() ([]byte, error) {
 	appendVString(a.Account, &buf)
 
 	// serialize optional authorities (owner, active, posting)
-	// TODO: THIS IS UNTESTED
-	appendOptionalAuthority(a.Owner, &buf)
-	appendOptionalAuthority(a.Active, &buf)
-	appendOptionalAuthority(a.Posting, &buf)
+	err := appendOptionalAuthorities(&buf, a.Owner, a.Active, a.Posting)
+	if err != nil {
+		return nil, err
+	}
 
 	// memo key
 	//
@@ -261,6 +407,521 @@ func (a AccountUpdateOperation) SerializeOp() ([]byte, error) {
 	return buf.Bytes(), nil
 }
 
+func (o TransferToVestingOperation) SerializeOp() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte(opIdB(o.OpName()))
+	appendVString(o.From, &buf)
+	appendVString(o.To, &buf)
+	err := appendAsset(o.Amount, &buf)
+	if err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func (o WithdrawVestingOperation) SerializeOp() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte(opIdB(o.OpName()))
+	appendVString(o.Account, &buf)
+	err := appendAsset(o.VestingShares, &buf)
+	if err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func (o SetWithdrawVestingRouteOperation) SerializeOp() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte(opIdB(o.OpName()))
+	appendVString(o.FromAccount, &buf)
+	appendVString(o.ToAccount, &buf)
+	appendUint16(o.Percent, &buf)
+	appendBool(o.AutoVest, &buf)
+
+	return buf.Bytes(), nil
+}
+
+func (o DelegateVestingSharesOperation) SerializeOp() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte(opIdB(o.OpName()))
+	appendVString(o.Delegator, &buf)
+	appendVString(o.Delegatee, &buf)
+	err := appendAsset(o.VestingShares, &buf)
+	if err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func (o LimitOrderCreateOperation) SerializeOp() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte(opIdB(o.OpName()))
+	appendVString(o.Owner, &buf)
+	appendUint32(o.OrderId, &buf)
+	err := appendAsset(o.AmountToSell, &buf)
+	if err != nil {
+		return nil, err
+	}
+	err = appendAsset(o.MinToReceive, &buf)
+	if err != nil {
+		return nil, err
+	}
+	appendBool(o.FillOrKill, &buf)
+	appendTime(o.Expiration, &buf)
+
+	return buf.Bytes(), nil
+}
+
+func (o LimitOrderCreate2Operation) SerializeOp() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte(opIdB(o.OpName()))
+	appendVString(o.Owner, &buf)
+	appendUint32(o.OrderId, &buf)
+	err := appendAsset(o.AmountToSell, &buf)
+	if err != nil {
+		return nil, err
+	}
+	err = appendPrice(o.ExchangeRate, &buf)
+	if err != nil {
+		return nil, err
+	}
+	appendBool(o.FillOrKill, &buf)
+	appendTime(o.Expiration, &buf)
+
+	return buf.Bytes(), nil
+}
+
+func (o LimitOrderCancelOperation) SerializeOp() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte(opIdB(o.OpName()))
+	appendVString(o.Owner, &buf)
+	appendUint32(o.OrderId, &buf)
+
+	return buf.Bytes(), nil
+}
+
+func (o ConvertOperation) SerializeOp() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte(opIdB(o.OpName()))
+	appendVString(o.Owner, &buf)
+	appendUint32(o.RequestId, &buf)
+	err := appendAsset(o.Amount, &buf)
+	if err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func (o CollateralizedConvertOperation) SerializeOp() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte(opIdB(o.OpName()))
+	appendVString(o.Owner, &buf)
+	appendUint32(o.RequestId, &buf)
+	err := appendAsset(o.Amount, &buf)
+	if err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func (o EscrowTransferOperation) SerializeOp() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte(opIdB(o.OpName()))
+	appendVString(o.From, &buf)
+	appendVString(o.To, &buf)
+	err := appendAsset(o.HbdAmount, &buf)
+	if err != nil {
+		return nil, err
+	}
+	err = appendAsset(o.HiveAmount, &buf)
+	if err != nil {
+		return nil, err
+	}
+	appendUint32(o.EscrowId, &buf)
+	appendVString(o.Agent, &buf)
+	err = appendAsset(o.Fee, &buf)
+	if err != nil {
+		return nil, err
+	}
+	appendVString(o.JsonMeta, &buf)
+	appendTime(o.RatificationDeadline, &buf)
+	appendTime(o.EscrowExpiration, &buf)
+
+	return buf.Bytes(), nil
+}
+
+func (o EscrowApproveOperation) SerializeOp() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte(opIdB(o.OpName()))
+	appendVString(o.From, &buf)
+	appendVString(o.To, &buf)
+	appendVString(o.Agent, &buf)
+	appendVString(o.Who, &buf)
+	appendUint32(o.EscrowId, &buf)
+	appendBool(o.Approve, &buf)
+
+	return buf.Bytes(), nil
+}
+
+func (o EscrowDisputeOperation) SerializeOp() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte(opIdB(o.OpName()))
+	appendVString(o.From, &buf)
+	appendVString(o.To, &buf)
+	appendVString(o.Agent, &buf)
+	appendVString(o.Who, &buf)
+	appendUint32(o.EscrowId, &buf)
+
+	return buf.Bytes(), nil
+}
+
+func (o EscrowReleaseOperation) SerializeOp() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte(opIdB(o.OpName()))
+	appendVString(o.From, &buf)
+	appendVString(o.To, &buf)
+	appendVString(o.Agent, &buf)
+	appendVString(o.Who, &buf)
+	appendVString(o.Receiver, &buf)
+	appendUint32(o.EscrowId, &buf)
+	err := appendAsset(o.HbdAmount, &buf)
+	if err != nil {
+		return nil, err
+	}
+	err = appendAsset(o.HiveAmount, &buf)
+	if err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func (o WitnessUpdateOperation) SerializeOp() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte(opIdB(o.OpName()))
+	appendVString(o.Owner, &buf)
+	appendVString(o.Url, &buf)
+	err := appendPublicKey(o.BlockSigningKey, &buf)
+	if err != nil {
+		return nil, err
+	}
+	err = appendAsset(o.Props.AccountCreationFee, &buf)
+	if err != nil {
+		return nil, err
+	}
+	appendUint32(o.Props.MaximumBlockSize, &buf)
+	appendUint16(o.Props.HbdInterestRate, &buf)
+	err = appendAsset(o.Fee, &buf)
+	if err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func (o WitnessSetPropertiesOperation) SerializeOp() ([]byte, error) {
+	if err := o.Props.Err(); err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	buf.WriteByte(opIdB(o.OpName()))
+	appendVString(o.Owner, &buf)
+	keys := o.Props.keys()
+	WriteUvarint(&buf, uint64(len(keys)))
+	for _, key := range keys {
+		appendVString(key, &buf)
+		appendVBytes(o.Props.props[key], &buf)
+	}
+	err := appendExtensions(o.Extensions, &buf)
+	if err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func (o FeedPublishOperation) SerializeOp() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte(opIdB(o.OpName()))
+	appendVString(o.Publisher, &buf)
+	err := appendPrice(o.ExchangeRate, &buf)
+	if err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func (o AccountWitnessVoteOperation) SerializeOp() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte(opIdB(o.OpName()))
+	appendVString(o.Account, &buf)
+	appendVString(o.Witness, &buf)
+	appendBool(o.Approve, &buf)
+
+	return buf.Bytes(), nil
+}
+
+func (o AccountWitnessProxyOperation) SerializeOp() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte(opIdB(o.OpName()))
+	appendVString(o.Account, &buf)
+	appendVString(o.Proxy, &buf)
+
+	return buf.Bytes(), nil
+}
+
+func (o UpdateProposalVotesOperation) SerializeOp() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte(opIdB(o.OpName()))
+	appendVString(o.Voter, &buf)
+	err := appendProposalIds(o.ProposalIds, &buf)
+	if err != nil {
+		return nil, err
+	}
+	appendBool(o.Approve, &buf)
+	err = appendExtensions(o.Extensions, &buf)
+	if err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func (o CreateProposalOperation) SerializeOp() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte(opIdB(o.OpName()))
+	appendVString(o.Creator, &buf)
+	appendVString(o.Receiver, &buf)
+	appendTime(o.StartDate, &buf)
+	appendTime(o.EndDate, &buf)
+	err := appendAsset(o.DailyPay, &buf)
+	if err != nil {
+		return nil, err
+	}
+	appendVString(o.Subject, &buf)
+	appendVString(o.Permlink, &buf)
+	err = appendExtensions(o.Extensions, &buf)
+	if err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func (e UpdateProposalEndDate) SerializeExt() ([]byte, error) {
+	var buf bytes.Buffer
+	appendTime(e.EndDate, &buf)
+	return buf.Bytes(), nil
+}
+
+func (o UpdateProposalOperation) SerializeOp() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte(opIdB(o.OpName()))
+	err := binary.Write(&buf, binary.LittleEndian, o.ProposalId)
+	if err != nil {
+		return nil, err
+	}
+	appendVString(o.Creator, &buf)
+	err = appendAsset(o.DailyPay, &buf)
+	if err != nil {
+		return nil, err
+	}
+	appendVString(o.Subject, &buf)
+	appendVString(o.Permlink, &buf)
+	err = appendExtensions(o.Extensions, &buf)
+	if err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func (o RemoveProposalOperation) SerializeOp() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte(opIdB(o.OpName()))
+	appendVString(o.ProposalOwner, &buf)
+	err := appendProposalIds(o.ProposalIds, &buf)
+	if err != nil {
+		return nil, err
+	}
+	err = appendExtensions(o.Extensions, &buf)
+	if err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func (o AccountCreateOperation) SerializeOp() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte(opIdB(o.OpName()))
+	err := appendAsset(o.Fee, &buf)
+	if err != nil {
+		return nil, err
+	}
+	appendVString(o.Creator, &buf)
+	appendVString(o.NewAccountName, &buf)
+	err = serializeAuthorities(&buf, o.Owner, o.Active, o.Posting)
+	if err != nil {
+		return nil, err
+	}
+	err = appendPublicKey(o.MemoKey, &buf)
+	if err != nil {
+		return nil, err
+	}
+	appendVString(o.JsonMetadata, &buf)
+
+	return buf.Bytes(), nil
+}
+
+func (o AccountCreateWithDelegationOperation) SerializeOp() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte(opIdB(o.OpName()))
+	err := appendAsset(o.Fee, &buf)
+	if err != nil {
+		return nil, err
+	}
+	err = appendAsset(o.Delegation, &buf)
+	if err != nil {
+		return nil, err
+	}
+	appendVString(o.Creator, &buf)
+	appendVString(o.NewAccountName, &buf)
+	err = serializeAuthorities(&buf, o.Owner, o.Active, o.Posting)
+	if err != nil {
+		return nil, err
+	}
+	err = appendPublicKey(o.MemoKey, &buf)
+	if err != nil {
+		return nil, err
+	}
+	appendVString(o.JsonMetadata, &buf)
+	err = appendExtensions(o.Extensions, &buf)
+	if err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func (o ClaimAccountOperation) SerializeOp() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte(opIdB(o.OpName()))
+	appendVString(o.Creator, &buf)
+	err := appendAsset(o.Fee, &buf)
+	if err != nil {
+		return nil, err
+	}
+	err = appendExtensions(o.Extensions, &buf)
+	if err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func (o CreateClaimedAccountOperation) SerializeOp() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte(opIdB(o.OpName()))
+	appendVString(o.Creator, &buf)
+	appendVString(o.NewAccountName, &buf)
+	err := serializeAuthorities(&buf, o.Owner, o.Active, o.Posting)
+	if err != nil {
+		return nil, err
+	}
+	err = appendPublicKey(o.MemoKey, &buf)
+	if err != nil {
+		return nil, err
+	}
+	appendVString(o.JsonMetadata, &buf)
+	err = appendExtensions(o.Extensions, &buf)
+	if err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func (o RequestAccountRecoveryOperation) SerializeOp() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte(opIdB(o.OpName()))
+	appendVString(o.RecoveryAccount, &buf)
+	appendVString(o.AccountToRecover, &buf)
+	err := serializeAuthority(o.NewOwnerAuthority, &buf)
+	if err != nil {
+		return nil, err
+	}
+	err = appendExtensions(o.Extensions, &buf)
+	if err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func (o RecoverAccountOperation) SerializeOp() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte(opIdB(o.OpName()))
+	appendVString(o.AccountToRecover, &buf)
+	err := serializeAuthorities(&buf, o.NewOwnerAuthority, o.RecentOwnerAuthority)
+	if err != nil {
+		return nil, err
+	}
+	err = appendExtensions(o.Extensions, &buf)
+	if err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func (o ChangeRecoveryAccountOperation) SerializeOp() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte(opIdB(o.OpName()))
+	appendVString(o.AccountToRecover, &buf)
+	appendVString(o.NewRecoveryAccount, &buf)
+	err := appendExtensions(o.Extensions, &buf)
+	if err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func (o AccountUpdate2Operation) SerializeOp() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte(opIdB(o.OpName()))
+	appendVString(o.Account, &buf)
+	err := appendOptionalAuthorities(&buf, o.Owner, o.Active, o.Posting)
+	if err != nil {
+		return nil, err
+	}
+	if o.MemoKey != "" {
+		buf.WriteByte(1)
+		err = appendPublicKey(o.MemoKey, &buf)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		buf.WriteByte(0)
+	}
+	appendVString(o.JsonMetadata, &buf)
+	appendVString(o.PostingJsonMetadata, &buf)
+	err = appendExtensions(o.Extensions, &buf)
+	if err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
 func (o TransferToSavings) SerializeOp() ([]byte, error) {
 	// OperationSerializers.transfer_to_savings = OperationDataSerializer(32, [
 	// 	['from', StringSerializer],
@@ -273,7 +934,10 @@ func (o TransferToSavings) SerializeOp() ([]byte, error) {
 	buf.WriteByte(opIdB(o.OpName()))
 	appendVString(o.From, &buf)
 	appendVString(o.To, &buf)
-	appendVAsset(o.Amount, &buf)
+	err := appendAsset(o.Amount, &buf)
+	if err != nil {
+		return nil, err
+	}
 	appendVString(o.Memo, &buf)
 
 	return buf.Bytes(), nil
@@ -296,7 +960,10 @@ func (o TransferFromSavings) SerializeOp() ([]byte, error) {
 		return nil, err
 	}
 	appendVString(o.To, &buf)
-	appendVAsset(o.Amount, &buf)
+	err = appendAsset(o.Amount, &buf)
+	if err != nil {
+		return nil, err
+	}
 	appendVString(o.Memo, &buf)
 
 	return buf.Bytes(), nil
@@ -322,14 +989,55 @@ func (o CancelTransferFromSavings) SerializeOp() ([]byte, error) {
 	return buf.Bytes(), nil
 }
 
-// todo: UNTESTED
-func appendOptionalAuthority(auth *Auths, buf *bytes.Buffer) {
-	if auth != nil {
-		buf.WriteByte(1) // field is present, so we prepend a 1
-		serializeAuthority(*auth, buf)
-	} else {
+func (e RecurrentTransferPairId) SerializeExt() ([]byte, error) {
+	return []byte{e.PairId}, nil
+}
+
+func (o RecurrentTransferOperation) SerializeOp() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte(opIdB(o.OpName()))
+	appendVString(o.From, &buf)
+	appendVString(o.To, &buf)
+	err := appendAsset(o.Amount, &buf)
+	if err != nil {
+		return nil, err
+	}
+	appendVString(o.Memo, &buf)
+	appendUint16(o.Recurrence, &buf)
+	appendUint16(o.Executions, &buf)
+	err = appendExtensions(o.Extensions, &buf)
+	if err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func appendOptionalAuthority(auth *Authority, buf *bytes.Buffer) error {
+	if auth == nil {
 		buf.WriteByte(0) // field is absent, so we write a 0
+		return nil
 	}
+	buf.WriteByte(1) // field is present, so we prepend a 1
+	return serializeAuthority(*auth, buf)
+}
+
+func appendOptionalAuthorities(buf *bytes.Buffer, auths ...*Authority) error {
+	for _, auth := range auths {
+		if err := appendOptionalAuthority(auth, buf); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func serializeAuthorities(buf *bytes.Buffer, auths ...Authority) error {
+	for _, auth := range auths {
+		if err := serializeAuthority(auth, buf); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 // todo: UNTESTED
@@ -354,56 +1062,34 @@ func WriteVarint(w io.Writer, x int64) error {
 	return nil
 }
 
-// todo: UNTESTED
-func serializeAuthority(auth Auths, buf *bytes.Buffer) {
-	// write weight_threshold
-	err := binary.Write(buf, binary.LittleEndian, uint32(auth.WeightThreshold))
-	if err != nil {
-		fmt.Printf("Error writing weight_threshold: %v\n", err)
-		return
-	}
-
-	// write account_auths
-	err = WriteUvarint(buf, uint64(len(auth.AccountAuths)))
+// Writes the authority with its entries in canonical order, as the chain
+// stores them in flat_maps
+func serializeAuthority(auth Authority, buf *bytes.Buffer) error {
+	accountAuths, err := auth.sortedAccountAuths()
 	if err != nil {
-		log.Printf("error writing account_auths length: %v\n", err)
-		return
-	}
-	for _, accountAuth := range auth.AccountAuths {
-		appendVString(accountAuth[0].(string), buf)
-		err = binary.Write(buf, binary.LittleEndian, uint16(accountAuth[1].(int)))
-		if err != nil {
-			log.Printf("error writing account_auth weight: %v\n", err)
-			return
-		}
+		return err
 	}
-
-	// write key_auths
-	err = WriteUvarint(buf, uint64(len(auth.KeyAuths)))
+	keyAuths, err := auth.sortedKeyAuths()
 	if err != nil {
-		log.Printf("error writing key_auths length: %v\n", err)
-		return
+		return err
 	}
-	for _, keyAuth := range sortKeyAuth(auth.KeyAuths) {
-		pk, err := DecodePublicKey(keyAuth[0].(string))
 
-		if err != nil {
-			log.Printf("error decoding public key: %v\n", err)
-			return
-		}
+	appendUint32(auth.Threshold, buf)
 
-		binary.Write(buf, binary.LittleEndian, pk.SerializeCompressed())
-		err = binary.Write(buf, binary.LittleEndian, uint16(keyAuth[1].(int)))
-		if err != nil {
-			log.Printf("error writing key_auth weight: %v\n", err)
-			return
-		}
+	if err := WriteUvarint(buf, uint64(len(accountAuths))); err != nil {
+		return err
+	}
+	for _, accountAuth := range accountAuths {
+		appendVString(accountAuth.Account, buf)
+		appendUint16(accountAuth.Weight, buf)
 	}
-}
 
-func sortKeyAuth(auths [][2]interface{}) [][2]interface{} {
-	sort.Slice(auths, func(i, j int) bool {
-		return auths[i][0].(string) < auths[j][0].(string)
-	})
-	return auths
+	if err := WriteUvarint(buf, uint64(len(keyAuths))); err != nil {
+		return err
+	}
+	for _, keyAuth := range keyAuths {
+		buf.Write(keyAuth.key)
+		appendUint16(keyAuth.weight, buf)
+	}
+	return nil
 }
@@ -2,7 +2,9 @@ package hivego
 
 import (
 	"bytes"
+	"encoding/json"
 	"testing"
+	"time"
 )
 
 func TestOpIdB(t *testing.T) {
@@ -121,3 +123,613 @@ func TestSerializeOpTransfer(t *testing.T) {
 		t.Error("Expected", expected, "got", got)
 	}
 }
+
+func TestSerializeOpComment(t *testing.T) {
+	got, _ := getTestCommentOp().SerializeOp()
+	expected := []byte{1, 0, 4, 104, 105, 118, 101, 5, 120, 101, 114, 111, 99, 6, 112, 105, 115, 116, 111, 110, 1, 84, 1, 66, 2, 123, 125}
+	if !bytes.Equal(got, expected) {
+		t.Error("Expected", expected, "got", got)
+	}
+}
+
+func TestSerializeOpDeleteComment(t *testing.T) {
+	got, _ := DeleteCommentOperation{Author: "xeroc", Permlink: "piston"}.SerializeOp()
+	expected := []byte{17, 5, 120, 101, 114, 111, 99, 6, 112, 105, 115, 116, 111, 110}
+	if !bytes.Equal(got, expected) {
+		t.Error("Expected", expected, "got", got)
+	}
+}
+
+func TestSerializeOpCommentOptions(t *testing.T) {
+	op := NewCommentOptions("xeroc", "piston")
+	op.SetBeneficiaries([]Beneficiary{{"bob", 1000}, {"alice", 500}})
+	got, err := op.SerializeOp()
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []byte{19, 5, 120, 101, 114, 111, 99, 6, 112, 105, 115, 116, 111, 110,
+		0, 202, 154, 59, 0, 0, 0, 0, 3, 32, 188, 190,
+		16, 39, 1, 1,
+		1, 0, 2, 5, 97, 108, 105, 99, 101, 244, 1, 3, 98, 111, 98, 232, 3}
+	if !bytes.Equal(got, expected) {
+		t.Error("Expected", expected, "got", got)
+	}
+
+	jsonB, err := json.Marshal(op.Extensions)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectedJson := `[[0,{"beneficiaries":[{"account":"alice","weight":500},{"account":"bob","weight":1000}]}]]`
+	if string(jsonB) != expectedJson {
+		t.Error("Expected", expectedJson, "got", string(jsonB))
+	}
+}
+
+func TestSerializeOpCommentOptionsInvalidBeneficiaries(t *testing.T) {
+	op := NewCommentOptions("xeroc", "piston")
+	op.SetBeneficiaries([]Beneficiary{{"bob", 6000}, {"alice", 5000}})
+	if _, err := op.SerializeOp(); err == nil {
+		t.Error("Expected an error for weights above 10000")
+	}
+
+	op.SetBeneficiaries([]Beneficiary{{"bob", 100}, {"bob", 100}})
+	if _, err := op.SerializeOp(); err == nil {
+		t.Error("Expected an error for duplicate beneficiaries")
+	}
+
+	op.Extensions = Extensions{CommentPayoutBeneficiaries{}}
+	if _, err := op.SerializeOp(); err == nil {
+		t.Error("Expected an error for an empty beneficiaries list")
+	}
+	op.SetBeneficiaries(nil)
+	if len(op.Extensions) != 0 {
+		t.Error("Expected an empty list to remove the extension, got", op.Extensions)
+	}
+}
+
+func TestAppendExtensionsEmpty(t *testing.T) {
+	var buf bytes.Buffer
+	appendExtensions(nil, &buf)
+	expected := []byte{0}
+	if !bytes.Equal(buf.Bytes(), expected) {
+		t.Error("Expected", expected, "got", buf.Bytes())
+	}
+}
+
+func TestSerializeOpTransferToVesting(t *testing.T) {
+	got, _ := TransferToVestingOperation{From: "xeroc", To: "bob", Amount: MustParseAsset("1.000 HIVE")}.SerializeOp()
+	expected := []byte{3, 5, 120, 101, 114, 111, 99, 3, 98, 111, 98, 232, 3, 0, 0, 0, 0, 0, 0, 35, 32, 188, 190}
+	if !bytes.Equal(got, expected) {
+		t.Error("Expected", expected, "got", got)
+	}
+}
+
+func TestSerializeOpWithdrawVesting(t *testing.T) {
+	got, _ := WithdrawVestingOperation{Account: "xeroc", VestingShares: MustParseAsset("1.000000 VESTS")}.SerializeOp()
+	expected := []byte{4, 5, 120, 101, 114, 111, 99, 64, 66, 15, 0, 0, 0, 0, 0, 70, 32, 188, 190}
+	if !bytes.Equal(got, expected) {
+		t.Error("Expected", expected, "got", got)
+	}
+}
+
+func TestSerializeOpSetWithdrawVestingRoute(t *testing.T) {
+	got, _ := SetWithdrawVestingRouteOperation{FromAccount: "xeroc", ToAccount: "bob", Percent: 5000, AutoVest: true}.SerializeOp()
+	expected := []byte{20, 5, 120, 101, 114, 111, 99, 3, 98, 111, 98, 136, 19, 1}
+	if !bytes.Equal(got, expected) {
+		t.Error("Expected", expected, "got", got)
+	}
+}
+
+func TestSerializeOpDelegateVestingShares(t *testing.T) {
+	got, _ := DelegateVestingSharesOperation{Delegator: "xeroc", Delegatee: "bob", VestingShares: MustParseAsset("1.000000 VESTS")}.SerializeOp()
+	expected := []byte{40, 5, 120, 101, 114, 111, 99, 3, 98, 111, 98, 64, 66, 15, 0, 0, 0, 0, 0, 70, 32, 188, 190}
+	if !bytes.Equal(got, expected) {
+		t.Error("Expected", expected, "got", got)
+	}
+}
+
+func TestFormatLegacyAsset(t *testing.T) {
+	cases := []struct {
+		got      string
+		expected string
+	}{
+		{formatLegacyAsset(1000, 3, "HIVE"), "1.000 HIVE"},
+		{formatLegacyAsset(5, 6, "VESTS"), "0.000005 VESTS"},
+		{formatLegacyAsset(-1500, 3, "HBD"), "-1.500 HBD"},
+		{formatLegacyAsset(0, 3, "HBD"), "0.000 HBD"},
+	}
+	for _, c := range cases {
+		if c.got != c.expected {
+			t.Error("Expected", c.expected, "got", c.got)
+		}
+	}
+}
+
+func TestSerializeOpLimitOrderCreate(t *testing.T) {
+	op := LimitOrderCreateOperation{
+		Owner:        "xeroc",
+		OrderId:      1,
+		AmountToSell: MustParseAsset("1.000 HIVE"),
+		MinToReceive: MustParseAsset("0.250 HBD"),
+		FillOrKill:   false,
+		Expiration:   getTestExpiration(),
+	}
+	got, _ := op.SerializeOp()
+	expected := []byte{5, 5, 120, 101, 114, 111, 99, 1, 0, 0, 0,
+		232, 3, 0, 0, 0, 0, 0, 0, 35, 32, 188, 190,
+		250, 0, 0, 0, 0, 0, 0, 0, 3, 32, 188, 190,
+		0, 241, 121, 168, 87}
+	if !bytes.Equal(got, expected) {
+		t.Error("Expected", expected, "got", got)
+	}
+
+	jsonB, _ := json.Marshal(op)
+	expectedJson := `{"owner":"xeroc","orderid":1,"amount_to_sell":"1.000 HIVE","min_to_receive":"0.250 HBD","fill_or_kill":false,"expiration":"2016-08-08T12:24:17"}`
+	if string(jsonB) != expectedJson {
+		t.Error("Expected", expectedJson, "got", string(jsonB))
+	}
+}
+
+func TestSerializeOpLimitOrderCreate2(t *testing.T) {
+	op := LimitOrderCreate2Operation{
+		Owner:        "xeroc",
+		OrderId:      1,
+		AmountToSell: MustParseAsset("1.000 HIVE"),
+		ExchangeRate: Price{Base: MustParseAsset("1.000 HIVE"), Quote: MustParseAsset("0.250 HBD")},
+		FillOrKill:   true,
+		Expiration:   getTestExpiration(),
+	}
+	got, _ := op.SerializeOp()
+	expected := []byte{21, 5, 120, 101, 114, 111, 99, 1, 0, 0, 0,
+		232, 3, 0, 0, 0, 0, 0, 0, 35, 32, 188, 190,
+		232, 3, 0, 0, 0, 0, 0, 0, 35, 32, 188, 190,
+		250, 0, 0, 0, 0, 0, 0, 0, 3, 32, 188, 190,
+		1, 241, 121, 168, 87}
+	if !bytes.Equal(got, expected) {
+		t.Error("Expected", expected, "got", got)
+	}
+}
+
+func TestSerializeOpLimitOrderCancel(t *testing.T) {
+	got, _ := LimitOrderCancelOperation{Owner: "xeroc", OrderId: 1}.SerializeOp()
+	expected := []byte{6, 5, 120, 101, 114, 111, 99, 1, 0, 0, 0}
+	if !bytes.Equal(got, expected) {
+		t.Error("Expected", expected, "got", got)
+	}
+}
+
+func TestOrderExpiration(t *testing.T) {
+	if _, err := orderExpiration(time.Time{}); err != nil {
+		t.Error("Expected the default expiration to be accepted, got", err)
+	}
+	if _, err := orderExpiration(time.Now().Add(27 * 24 * time.Hour)); err != nil {
+		t.Error("Expected 27 days to be accepted, got", err)
+	}
+	if _, err := orderExpiration(time.Now().Add(29 * 24 * time.Hour)); err == nil {
+		t.Error("Expected an error for an order expiring in 29 days")
+	}
+}
+
+func TestSerializeOpConvert(t *testing.T) {
+	got, _ := ConvertOperation{Owner: "xeroc", RequestId: 7, Amount: MustParseAsset("0.250 HBD")}.SerializeOp()
+	expected := []byte{8, 5, 120, 101, 114, 111, 99, 7, 0, 0, 0, 250, 0, 0, 0, 0, 0, 0, 0, 3, 32, 188, 190}
+	if !bytes.Equal(got, expected) {
+		t.Error("Expected", expected, "got", got)
+	}
+}
+
+func TestSerializeOpCollateralizedConvert(t *testing.T) {
+	got, _ := CollateralizedConvertOperation{Owner: "xeroc", RequestId: 7, Amount: MustParseAsset("1.000 HIVE")}.SerializeOp()
+	expected := []byte{48, 5, 120, 101, 114, 111, 99, 7, 0, 0, 0, 232, 3, 0, 0, 0, 0, 0, 0, 35, 32, 188, 190}
+	if !bytes.Equal(got, expected) {
+		t.Error("Expected", expected, "got", got)
+	}
+}
+
+func TestNaiAssetToAsset(t *testing.T) {
+	got, _ := naiAsset{Amount: "1234567", Precision: 6, Nai: "@@000000037"}.asset()
+	expected := "1.234567 VESTS"
+	if got.String() != expected {
+		t.Error("Expected", expected, "got", got)
+	}
+
+	_, err := naiAsset{Amount: "1", Precision: 3, Nai: "@@000000000"}.asset()
+	if err == nil {
+		t.Error("Expected an error for an unknown nai")
+	}
+
+	_, err = naiAsset{Amount: "1", Precision: 6, Nai: "@@000000021"}.asset()
+	if err == nil {
+		t.Error("Expected an error for a wrong precision")
+	}
+}
+
+func TestSerializeOpEscrowTransfer(t *testing.T) {
+	op := EscrowTransferOperation{
+		From:                 "xeroc",
+		To:                   "bob",
+		HbdAmount:            MustParseAsset("0.250 HBD"),
+		HiveAmount:           MustParseAsset("1.000 HIVE"),
+		EscrowId:             7,
+		Agent:                "eve",
+		Fee:                  MustParseAsset("0.001 HBD"),
+		JsonMeta:             "{}",
+		RatificationDeadline: getTestExpiration(),
+		EscrowExpiration:     getTestExpiration(),
+	}
+	got, _ := op.SerializeOp()
+	expected := []byte{27, 5, 120, 101, 114, 111, 99, 3, 98, 111, 98,
+		250, 0, 0, 0, 0, 0, 0, 0, 3, 32, 188, 190,
+		232, 3, 0, 0, 0, 0, 0, 0, 35, 32, 188, 190,
+		7, 0, 0, 0, 3, 101, 118, 101,
+		1, 0, 0, 0, 0, 0, 0, 0, 3, 32, 188, 190,
+		2, 123, 125, 241, 121, 168, 87, 241, 121, 168, 87}
+	if !bytes.Equal(got, expected) {
+		t.Error("Expected", expected, "got", got)
+	}
+}
+
+func TestSerializeOpEscrowRelease(t *testing.T) {
+	op := EscrowReleaseOperation{"xeroc", "bob", "eve", "eve", "bob", 7, MustParseAsset("0.000 HBD"), MustParseAsset("1.000 HIVE")}
+	got, _ := op.SerializeOp()
+	expected := []byte{29, 5, 120, 101, 114, 111, 99, 3, 98, 111, 98, 3, 101, 118, 101, 3, 101, 118, 101, 3, 98, 111, 98,
+		7, 0, 0, 0,
+		0, 0, 0, 0, 0, 0, 0, 0, 3, 32, 188, 190,
+		232, 3, 0, 0, 0, 0, 0, 0, 35, 32, 188, 190}
+	if !bytes.Equal(got, expected) {
+		t.Error("Expected", expected, "got", got)
+	}
+}
+
+var testPubKey = "STM7dzxQo2aaav9weydSVAwqewcUz2GbUwyWrAVqkdiKsD6V1uX8B"
+var testPubKeyBytes = []byte{3, 106, 48, 22, 243, 45, 96, 255, 51, 197, 8, 179, 85, 147, 131, 32, 165, 214, 76, 64, 90, 168, 63, 67, 124, 7, 139, 26, 114, 145, 144, 94, 153}
+
+func TestSerializeOpWitnessUpdate(t *testing.T) {
+	op := WitnessUpdateOperation{
+		Owner:           "xeroc",
+		Url:             "u",
+		BlockSigningKey: testPubKey,
+		Props:           ChainProperties{AccountCreationFee: MustParseAsset("3.000 HIVE"), MaximumBlockSize: 65536, HbdInterestRate: 0},
+		Fee:             MustParseAsset("0.000 HIVE"),
+	}
+	got, _ := op.SerializeOp()
+	expected := []byte{11, 5, 120, 101, 114, 111, 99, 1, 117}
+	expected = append(expected, testPubKeyBytes...)
+	expected = append(expected,
+		184, 11, 0, 0, 0, 0, 0, 0, 35, 32, 188, 190,
+		0, 0, 1, 0, 0, 0,
+		0, 0, 0, 0, 0, 0, 0, 0, 35, 32, 188, 190)
+	if !bytes.Equal(got, expected) {
+		t.Error("Expected", expected, "got", got)
+	}
+}
+
+func TestSerializeOpWitnessSetProperties(t *testing.T) {
+	props := NewWitnessProps(testPubKey).HbdInterestRate(1500).Url("u")
+	op := WitnessSetPropertiesOperation{Owner: "xeroc", Props: *props}
+	got, err := op.SerializeOp()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// properties are sorted by name: hbd_interest_rate, key, url
+	expected := []byte{42, 5, 120, 101, 114, 111, 99, 3,
+		17, 104, 98, 100, 95, 105, 110, 116, 101, 114, 101, 115, 116, 95, 114, 97, 116, 101, 2, 220, 5,
+		3, 107, 101, 121, 33}
+	expected = append(expected, testPubKeyBytes...)
+	expected = append(expected, 3, 117, 114, 108, 2, 1, 117, 0)
+	if !bytes.Equal(got, expected) {
+		t.Error("Expected", expected, "got", got)
+	}
+
+	jsonB, _ := json.Marshal(op)
+	expectedJson := `{"owner":"xeroc","props":[["hbd_interest_rate","dc05"],["key","036a3016f32d60ff33c508b355938320a5d64c405aa83f437c078b1a7291905e99"],["url","0175"]],"extensions":[]}`
+	if string(jsonB) != expectedJson {
+		t.Error("Expected", expectedJson, "got", string(jsonB))
+	}
+}
+
+func TestWitnessPropsErrors(t *testing.T) {
+	if err := NewWitnessProps("STMinvalid").Url("u").Err(); err == nil {
+		t.Error("Expected an error for an invalid signing key")
+	}
+	if err := (&WitnessProps{}).Err(); err == nil {
+		t.Error("Expected an error for missing signing key")
+	}
+	if err := NewWitnessProps(testPubKey).NewSigningKey(NullPublicKey).Err(); err != nil {
+		t.Error("Expected the null key to be accepted, got", err)
+	}
+}
+
+func TestSerializeOpFeedPublish(t *testing.T) {
+	op := FeedPublishOperation{Publisher: "xeroc", ExchangeRate: Price{Base: MustParseAsset("0.250 HBD"), Quote: MustParseAsset("1.000 HIVE")}}
+	got, _ := op.SerializeOp()
+	expected := []byte{7, 5, 120, 101, 114, 111, 99,
+		250, 0, 0, 0, 0, 0, 0, 0, 3, 32, 188, 190,
+		232, 3, 0, 0, 0, 0, 0, 0, 35, 32, 188, 190}
+	if !bytes.Equal(got, expected) {
+		t.Error("Expected", expected, "got", got)
+	}
+}
+
+func TestSerializeOpAccountWitnessVote(t *testing.T) {
+	got, _ := AccountWitnessVoteOperation{Account: "xeroc", Witness: "bob", Approve: true}.SerializeOp()
+	expected := []byte{12, 5, 120, 101, 114, 111, 99, 3, 98, 111, 98, 1}
+	if !bytes.Equal(got, expected) {
+		t.Error("Expected", expected, "got", got)
+	}
+}
+
+func TestSerializeOpAccountWitnessProxy(t *testing.T) {
+	got, _ := AccountWitnessProxyOperation{Account: "xeroc", Proxy: ""}.SerializeOp()
+	expected := []byte{13, 5, 120, 101, 114, 111, 99, 0}
+	if !bytes.Equal(got, expected) {
+		t.Error("Expected", expected, "got", got)
+	}
+}
+
+func TestSerializeOpUpdateProposalVotes(t *testing.T) {
+	got, _ := UpdateProposalVotesOperation{Voter: "xeroc", ProposalIds: []int64{300, 2, 300}, Approve: true}.SerializeOp()
+	expected := []byte{45, 5, 120, 101, 114, 111, 99, 2,
+		2, 0, 0, 0, 0, 0, 0, 0,
+		44, 1, 0, 0, 0, 0, 0, 0,
+		1, 0}
+	if !bytes.Equal(got, expected) {
+		t.Error("Expected", expected, "got", got)
+	}
+}
+
+func TestSerializeOpCreateProposal(t *testing.T) {
+	op := CreateProposalOperation{
+		Creator:   "xeroc",
+		Receiver:  "bob",
+		StartDate: getTestExpiration(),
+		EndDate:   getTestExpiration(),
+		DailyPay:  MustParseAsset("0.250 HBD"),
+		Subject:   "s",
+		Permlink:  "p",
+	}
+	got, _ := op.SerializeOp()
+	expected := []byte{44, 5, 120, 101, 114, 111, 99, 3, 98, 111, 98,
+		241, 121, 168, 87, 241, 121, 168, 87,
+		250, 0, 0, 0, 0, 0, 0, 0, 3, 32, 188, 190,
+		1, 115, 1, 112, 0}
+	if !bytes.Equal(got, expected) {
+		t.Error("Expected", expected, "got", got)
+	}
+}
+
+func TestSerializeOpUpdateProposal(t *testing.T) {
+	op := UpdateProposalOperation{
+		ProposalId: 300,
+		Creator:    "xeroc",
+		DailyPay:   MustParseAsset("0.250 HBD"),
+		Subject:    "s",
+		Permlink:   "p",
+		Extensions: Extensions{UpdateProposalEndDate{getTestExpiration()}},
+	}
+	got, _ := op.SerializeOp()
+	expected := []byte{47, 44, 1, 0, 0, 0, 0, 0, 0, 5, 120, 101, 114, 111, 99,
+		250, 0, 0, 0, 0, 0, 0, 0, 3, 32, 188, 190,
+		1, 115, 1, 112,
+		1, 1, 241, 121, 168, 87}
+	if !bytes.Equal(got, expected) {
+		t.Error("Expected", expected, "got", got)
+	}
+
+	jsonB, _ := json.Marshal(op.Extensions)
+	expectedJson := `[[1,{"end_date":"2016-08-08T12:24:17"}]]`
+	if string(jsonB) != expectedJson {
+		t.Error("Expected", expectedJson, "got", string(jsonB))
+	}
+}
+
+func TestSerializeOpRemoveProposal(t *testing.T) {
+	got, _ := RemoveProposalOperation{ProposalOwner: "xeroc", ProposalIds: []int64{2}}.SerializeOp()
+	expected := []byte{46, 5, 120, 101, 114, 111, 99, 1, 2, 0, 0, 0, 0, 0, 0, 0, 0}
+	if !bytes.Equal(got, expected) {
+		t.Error("Expected", expected, "got", got)
+	}
+}
+
+func TestSerializeOpCreateClaimedAccount(t *testing.T) {
+	op := CreateClaimedAccountOperation{
+		Creator:        "xeroc",
+		NewAccountName: "bob",
+		Owner:          NewKeyAuthority(testPubKey),
+		Active:         NewKeyAuthority(testPubKey),
+		Posting:        NewKeyAuthority(testPubKey),
+		MemoKey:        testPubKey,
+		JsonMetadata:   "",
+	}
+	got, err := op.SerializeOp()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	auth := append([]byte{1, 0, 0, 0, 0, 1}, testPubKeyBytes...)
+	auth = append(auth, 1, 0)
+	expected := []byte{23, 5, 120, 101, 114, 111, 99, 3, 98, 111, 98}
+	expected = append(expected, auth...)
+	expected = append(expected, auth...)
+	expected = append(expected, auth...)
+	expected = append(expected, testPubKeyBytes...)
+	expected = append(expected, 0, 0)
+	if !bytes.Equal(got, expected) {
+		t.Error("Expected", expected, "got", got)
+	}
+}
+
+func TestSerializeOpClaimAccount(t *testing.T) {
+	got, _ := ClaimAccountOperation{Creator: "xeroc", Fee: MustParseAsset("0.000 HIVE")}.SerializeOp()
+	expected := []byte{22, 5, 120, 101, 114, 111, 99, 0, 0, 0, 0, 0, 0, 0, 0, 35, 32, 188, 190, 0}
+	if !bytes.Equal(got, expected) {
+		t.Error("Expected", expected, "got", got)
+	}
+}
+
+func TestSerializeOpRecoverAccount(t *testing.T) {
+	recovery := NewAccountRecovery("xeroc", "bob", testPubKey)
+	got, err := recovery.RecoverOperation(NewKeyAuthority(testPubKey)).SerializeOp()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	auth := append([]byte{1, 0, 0, 0, 0, 1}, testPubKeyBytes...)
+	auth = append(auth, 1, 0)
+	expected := []byte{25, 5, 120, 101, 114, 111, 99}
+	expected = append(expected, auth...)
+	expected = append(expected, auth...)
+	expected = append(expected, 0)
+	if !bytes.Equal(got, expected) {
+		t.Error("Expected", expected, "got", got)
+	}
+}
+
+func TestSerializeOpRequestAccountRecovery(t *testing.T) {
+	recovery := NewAccountRecovery("xeroc", "bob", testPubKey)
+	got, err := recovery.RequestOperation().SerializeOp()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []byte{24, 3, 98, 111, 98, 5, 120, 101, 114, 111, 99, 1, 0, 0, 0, 0, 1}
+	expected = append(expected, testPubKeyBytes...)
+	expected = append(expected, 1, 0, 0)
+	if !bytes.Equal(got, expected) {
+		t.Error("Expected", expected, "got", got)
+	}
+}
+
+func TestSerializeOpChangeRecoveryAccount(t *testing.T) {
+	got, _ := ChangeRecoveryAccountOperation{AccountToRecover: "xeroc", NewRecoveryAccount: "bob"}.SerializeOp()
+	expected := []byte{26, 5, 120, 101, 114, 111, 99, 3, 98, 111, 98, 0}
+	if !bytes.Equal(got, expected) {
+		t.Error("Expected", expected, "got", got)
+	}
+}
+
+func TestSerializeOpAccountUpdate2(t *testing.T) {
+	op := AccountUpdate2Operation{Account: "xeroc", PostingJsonMetadata: "{}"}
+	got, _ := op.SerializeOp()
+	expected := []byte{43, 5, 120, 101, 114, 111, 99, 0, 0, 0, 0, 0, 2, 123, 125, 0}
+	if !bytes.Equal(got, expected) {
+		t.Error("Expected", expected, "got", got)
+	}
+
+	op.MemoKey = testPubKey
+	got, _ = op.SerializeOp()
+	expected = append([]byte{43, 5, 120, 101, 114, 111, 99, 0, 0, 0, 1}, testPubKeyBytes...)
+	expected = append(expected, 0, 2, 123, 125, 0)
+	if !bytes.Equal(got, expected) {
+		t.Error("Expected", expected, "got", got)
+	}
+
+	jsonB, _ := json.Marshal(AccountUpdate2Operation{Account: "xeroc", PostingJsonMetadata: "{}"})
+	expectedJson := `{"account":"xeroc","json_metadata":"","posting_json_metadata":"{}","extensions":[]}`
+	if string(jsonB) != expectedJson {
+		t.Error("Expected", expectedJson, "got", string(jsonB))
+	}
+}
+
+func TestSerializeOpRecurrentTransfer(t *testing.T) {
+	op := RecurrentTransferOperation{
+		From:       "xeroc",
+		To:         "bob",
+		Amount:     MustParseAsset("0.250 HBD"),
+		Memo:       "m",
+		Recurrence: 24,
+		Executions: 12,
+		Extensions: Extensions{RecurrentTransferPairId{3}},
+	}
+	got, _ := op.SerializeOp()
+	expected := []byte{49, 5, 120, 101, 114, 111, 99, 3, 98, 111, 98,
+		250, 0, 0, 0, 0, 0, 0, 0, 3, 32, 188, 190,
+		1, 109, 24, 0, 12, 0, 1, 0, 3}
+	if !bytes.Equal(got, expected) {
+		t.Error("Expected", expected, "got", got)
+	}
+
+	jsonB, _ := json.Marshal(op.Extensions)
+	expectedJson := `[[0,{"pair_id":3}]]`
+	if string(jsonB) != expectedJson {
+		t.Error("Expected", expectedJson, "got", string(jsonB))
+	}
+}
+
+func TestSerializeOpCustom(t *testing.T) {
+	op := CustomOperation{
+		RequiredAuths: []string{"xeroc"},
+		Id:            777,
+		Data:          HexBytes{0xde, 0xad},
+	}
+	got, _ := op.SerializeOp()
+	expected := []byte{15, 1, 5, 120, 101, 114, 111, 99, 9, 3, 2, 0xde, 0xad}
+	if !bytes.Equal(got, expected) {
+		t.Error("Expected", expected, "got", got)
+	}
+
+	jsonB, _ := json.Marshal(op)
+	expectedJson := `{"required_auths":["xeroc"],"id":777,"data":"dead"}`
+	if string(jsonB) != expectedJson {
+		t.Error("Expected", expectedJson, "got", string(jsonB))
+	}
+
+	// required_auths is a flat_set, sorted and without duplicates on chain
+	op.RequiredAuths = []string{"xeroc", "bob", "xeroc"}
+	got, _ = op.SerializeOp()
+	expected = []byte{15, 2, 3, 98, 111, 98, 5, 120, 101, 114, 111, 99, 9, 3, 2, 0xde, 0xad}
+	if !bytes.Equal(got, expected) {
+		t.Error("Expected", expected, "got", got)
+	}
+}
+
+func TestAppendVStringArrayVarintLength(t *testing.T) {
+	a := make([]string, 130)
+	var buf bytes.Buffer
+	got := appendVStringArray(a, &buf).Bytes()
+	if len(got) != 2+130 || got[0] != 0x82 || got[1] != 0x01 {
+		t.Error("Expected a 2 byte varint length, got", got[:2])
+	}
+}
+
+func TestSerializeOpCustomBinary(t *testing.T) {
+	op := CustomBinaryOperation{
+		RequiredOwnerAuths:   []string{},
+		RequiredActiveAuths:  []string{},
+		RequiredPostingAuths: []string{"xeroc"},
+		RequiredAuths: []Authority{{
+			Threshold:    1,
+			AccountAuths: []AccountAuth{{"bob", 1}},
+			KeyAuths:     []KeyAuth{},
+		}},
+		Id:   "app",
+		Data: HexBytes{0x01},
+	}
+	got, _ := op.SerializeOp()
+	expected := []byte{35, 0, 0, 1, 5, 120, 101, 114, 111, 99,
+		1, 1, 0, 0, 0, 1, 3, 98, 111, 98, 1, 0, 0,
+		3, 97, 112, 112, 1, 1}
+	if !bytes.Equal(got, expected) {
+		t.Error("Expected", expected, "got", got)
+	}
+}
+
+func TestSerializeOpAccountUpdateAuthorities(t *testing.T) {
+	owner := NewKeyAuthority(testPubKey)
+	op := AccountUpdateOperation{
+		Account:      "xeroc",
+		Owner:        &owner,
+		MemoKey:      testPubKey,
+		JsonMetadata: "",
+	}
+	got, err := op.SerializeOp()
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []byte{10, 5, 120, 101, 114, 111, 99, 1, 1, 0, 0, 0, 0, 1}
+	expected = append(append(expected, testPubKeyBytes...), 1, 0, 0, 0)
+	expected = append(append(expected, testPubKeyBytes...), 0)
+	if !bytes.Equal(got, expected) {
+		t.Error("Expected", expected, "got", got)
+	}
+}
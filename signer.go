@@ -7,6 +7,7 @@ import (
 	"encoding/hex"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"time"
 
 	"github.com/decred/base58"
@@ -25,7 +26,7 @@ func (h *HiveRpcNode) GetSigningData() (signingDataFromChain, error) {
 		return signingDataFromChain{}, err
 	}
 
-	var props globalProps
+	var props GlobalProps
 	err = json.Unmarshal(propsB, &props)
 	if err != nil {
 		return signingDataFromChain{}, err
@@ -87,6 +88,123 @@ func SignDigest(digest []byte, wif *string) ([]byte, error) {
 	return secp256k1.SignCompact(keyPair.PrivateKey, digest, true)
 }
 
+// Returns the public key that made the hex compact signature sig of digest
+func RecoverPublicKey(digest []byte, sig string) (string, error) {
+	sigB, err := hex.DecodeString(sig)
+	if err != nil {
+		return "", fmt.Errorf("invalid signature %q: %w", sig, err)
+	}
+	if len(sigB) != 65 {
+		return "", fmt.Errorf("invalid signature %q: expected 65 bytes", sig)
+	}
+	pubKey, _, err := secp256k1.RecoverCompact(sigB, digest)
+	if err != nil {
+		return "", fmt.Errorf("invalid signature %q: %w", sig, err)
+	}
+	return *GetPublicKeyString(pubKey), nil
+}
+
+// Checks that the hex compact signature sig of digest was made by pubKey
+func VerifySignature(digest []byte, sig string, pubKey string) (bool, error) {
+	expected, err := DecodePublicKey(pubKey)
+	if err != nil {
+		return false, err
+	}
+	signer, err := RecoverPublicKey(digest, sig)
+	if err != nil {
+		return false, err
+	}
+	return signer == *GetPublicKeyString(expected), nil
+}
+
+// Returns the public keys of the signatures over the HF26 serialization of
+// the transaction, in the same order, for the given chain id (mainnet if
+// omitted). Since HF26 the chain also accepts signatures over the legacy
+// binary serialization, which recover to unrelated keys here when the
+// transaction has assets; use SignerKeysBySerialization for those.
+func (t *HiveTransaction) SignerKeys(chainId ...string) ([]string, error) {
+	message, err := SerializeTx(*t)
+	if err != nil {
+		return nil, err
+	}
+	return t.recoverSigners(HashTxForSig(message, chainId...))
+}
+
+// Returns the public keys of the signatures over the HF26 serialization and
+// over the legacy one, or a nil legacy when both serializations are the same
+func (t *HiveTransaction) SignerKeysBySerialization(chainId ...string) (hf26 []string, legacy []string, err error) {
+	message, err := SerializeTx(*t)
+	if err != nil {
+		return nil, nil, err
+	}
+	hf26, err = t.recoverSigners(HashTxForSig(message, chainId...))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	legacyMessage, err := legacySerialization(message, firstChainId(chainId))
+	if err != nil {
+		return nil, nil, err
+	}
+	if bytes.Equal(legacyMessage, message) {
+		return hf26, nil, nil
+	}
+	legacy, err = t.recoverSigners(HashTxForSig(legacyMessage, chainId...))
+	if err != nil {
+		return nil, nil, err
+	}
+	return hf26, legacy, nil
+}
+
+func (t *HiveTransaction) recoverSigners(digest []byte) ([]string, error) {
+	keys := make([]string, 0, len(t.Signatures))
+	for _, sig := range t.Signatures {
+		key, err := RecoverPublicKey(digest, sig)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+// Returns the public keys of the signatures over the HF26 serialization of a
+// transaction from a block, see HiveTransaction.SignerKeys
+func (t Transaction) SignerKeys(chainId ...string) ([]string, error) {
+	tx, err := t.hiveTransaction()
+	if err != nil {
+		return nil, err
+	}
+	return tx.SignerKeys(chainId...)
+}
+
+// Returns the public keys of the signatures of a transaction from a block
+// for both serializations, see HiveTransaction.SignerKeysBySerialization
+func (t Transaction) SignerKeysBySerialization(chainId ...string) (hf26 []string, legacy []string, err error) {
+	tx, err := t.hiveTransaction()
+	if err != nil {
+		return nil, nil, err
+	}
+	return tx.SignerKeysBySerialization(chainId...)
+}
+
+func (t Transaction) hiveTransaction() (HiveTransaction, error) {
+	if len(t.Extensions) > 0 {
+		return HiveTransaction{}, errors.New("transaction extensions are not supported")
+	}
+	ops, err := t.DecodeOperations()
+	if err != nil {
+		return HiveTransaction{}, err
+	}
+	return HiveTransaction{
+		RefBlockNum:    t.RefBlockNum,
+		RefBlockPrefix: t.RefBlockPrefix,
+		Expiration:     t.Expiration,
+		Operations:     ops,
+		Signatures:     t.Signatures,
+	}, nil
+}
+
 func GphBase58CheckDecode(input string) ([]byte, [1]byte, error) {
 	decoded := base58.Decode(input)
 	if len(decoded) < 6 {
@@ -2,6 +2,7 @@ package hivego
 
 import (
 	"bytes"
+	"encoding/hex"
 	"testing"
 )
 
@@ -40,3 +41,158 @@ func TestGphBase58CheckDecode(t *testing.T) {
 		t.Error("Expected", expected1, "and", expected2, "got", got1, "and", got2)
 	}
 }
+
+func TestToWif(t *testing.T) {
+	wif := "5JuMt237G3m3BaT7zH4YdoycUtbw4AEPy6DLdCrKAnFGAtXyQ1W"
+	keyPair, _ := KeyPairFromWif(wif)
+	got := keyPair.ToWif()
+	if got != wif {
+		t.Error("Expected", wif, "got", got)
+	}
+}
+
+func TestAccountKeysFromPassword(t *testing.T) {
+	keys := AccountKeysFromPassword("xeroc", "P5secret")
+	again := AccountKeysFromPassword("xeroc", "P5secret")
+	if keys.Active.ToWif() != again.Active.ToWif() {
+		t.Error("Expected password derived keys to be deterministic")
+	}
+	if keys.Active.ToWif() == keys.Posting.ToWif() {
+		t.Error("Expected different keys for different roles")
+	}
+
+	seed := []byte("xeroc" + "active" + "P5secret")
+	expected := KeyPairFromBytes(HashTx(seed))
+	if !bytes.Equal(keys.Active.PrivateKey.Serialize(), expected.PrivateKey.Serialize()) {
+		t.Error("Expected the active key to be sha256(account + role + password)")
+	}
+}
+
+func TestRecoverPublicKey(t *testing.T) {
+	wif := "5JuMt237G3m3BaT7zH4YdoycUtbw4AEPy6DLdCrKAnFGAtXyQ1W"
+	keyPair, _ := KeyPairFromWif(wif)
+	digest := HashTxForSig([]byte("message"))
+	sig, _ := SignDigest(digest, &wif)
+
+	got, err := RecoverPublicKey(digest, hex.EncodeToString(sig))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != *keyPair.GetPublicKeyString() {
+		t.Error("Expected", *keyPair.GetPublicKeyString(), "got", got)
+	}
+
+	for _, invalid := range []string{"zz", "1f00", hex.EncodeToString(make([]byte, 65))} {
+		if _, err := RecoverPublicKey(digest, invalid); err == nil {
+			t.Error("Expected an error for", invalid)
+		}
+	}
+}
+
+func TestVerifySignature(t *testing.T) {
+	wif := "5JuMt237G3m3BaT7zH4YdoycUtbw4AEPy6DLdCrKAnFGAtXyQ1W"
+	keyPair, _ := KeyPairFromWif(wif)
+	digest := HashTxForSig([]byte("message"))
+	sigB, _ := SignDigest(digest, &wif)
+	sig := hex.EncodeToString(sigB)
+
+	if ok, err := VerifySignature(digest, sig, *keyPair.GetPublicKeyString()); err != nil || !ok {
+		t.Error("Expected the signature to verify", err)
+	}
+	other := *KeyPairFromPassword("alice", "active", "pass").GetPublicKeyString()
+	if ok, err := VerifySignature(digest, sig, other); err != nil || ok {
+		t.Error("Expected the signature not to verify for another key", err)
+	}
+	if ok, _ := VerifySignature(HashTxForSig([]byte("other message")), sig, *keyPair.GetPublicKeyString()); ok {
+		t.Error("Expected the signature not to verify for another digest")
+	}
+	if _, err := VerifySignature(digest, sig, "STM1"); err == nil {
+		t.Error("Expected an error for an invalid key")
+	}
+}
+
+func TestSignerKeys(t *testing.T) {
+	wif := "5JuMt237G3m3BaT7zH4YdoycUtbw4AEPy6DLdCrKAnFGAtXyQ1W"
+	keyPair, _ := KeyPairFromWif(wif)
+	tx := getTestVoteTx()
+	message, _ := SerializeTx(tx)
+	sig, _ := SignDigest(HashTxForSig(message), &wif)
+	tx.AddSig(hex.EncodeToString(sig))
+
+	keys, err := tx.SignerKeys()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(keys) != 1 || keys[0] != *keyPair.GetPublicKeyString() {
+		t.Error("Expected", *keyPair.GetPublicKeyString(), "got", keys)
+	}
+
+	// the same transaction as included in a block
+	blockTx := Transaction{
+		Expiration: tx.Expiration,
+		Extensions: []interface{}{},
+		Operations: []Operation{{
+			Type:  "vote_operation",
+			Value: map[string]interface{}{"voter": "xeroc", "author": "xeroc", "permlink": "piston", "weight": 10000},
+		}},
+		RefBlockNum:    tx.RefBlockNum,
+		RefBlockPrefix: tx.RefBlockPrefix,
+		Signatures:     tx.Signatures,
+	}
+	blockKeys, err := blockTx.SignerKeys()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(blockKeys) != 1 || blockKeys[0] != keys[0] {
+		t.Error("Expected", keys, "got", blockKeys)
+	}
+
+	testnetKeys, err := tx.SignerKeys("18dcf0a285365fc58b71f18b3d3fec954aa0c141c44e4e5cb4cf777b9eab274e")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if testnetKeys[0] == keys[0] {
+		t.Error("Expected another key for another chain id")
+	}
+}
+
+func TestSignerKeysLegacySerialization(t *testing.T) {
+	// transfer serialized by python-steem in the legacy form, signed over
+	// those bytes the way tools other than hivego sign
+	legacy, _ := hex.DecodeString("f68585abf4dce7c80457010203666f6f046261617206b201000000000003535445454d000004466f6f6f00")
+	wif := "5KQwrPbwdL6PhXujxW37FSSQZ1JiwsST4cqQzDeyXtP79zkvFD3"
+	keyPair, _ := KeyPairFromWif(wif)
+	sig, _ := SignDigest(HashTxForSig(legacy), &wif)
+
+	tx, err := DeserializeTx(legacy)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tx.AddSig(hex.EncodeToString(sig))
+
+	message, _ := SerializeTx(tx)
+	if got, _ := legacySerialization(message, ""); !bytes.Equal(got, legacy) {
+		t.Error("Expected", legacy, "got", got)
+	}
+
+	hf26, legacyKeys, err := tx.SignerKeysBySerialization()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(legacyKeys) != 1 || legacyKeys[0] != *keyPair.GetPublicKeyString() {
+		t.Error("Expected", *keyPair.GetPublicKeyString(), "got", legacyKeys)
+	}
+	if len(hf26) != 1 || hf26[0] == *keyPair.GetPublicKeyString() {
+		t.Error("Expected an unrelated key for the HF26 serialization, got", hf26)
+	}
+	if keys, err := tx.SignerKeys(); err != nil || len(keys) != 1 || keys[0] != hf26[0] {
+		t.Error("Expected the HF26 keys", hf26, "got", keys, err)
+	}
+
+	// without assets both serializations are the same
+	vote := getTestVoteTx()
+	_, legacyKeys, err = vote.SignerKeysBySerialization()
+	if err != nil || legacyKeys != nil {
+		t.Error("Expected no legacy keys for a transaction without assets, got", legacyKeys, err)
+	}
+}
@@ -3,7 +3,7 @@ package hivego
 import "time"
 
 func getTestVoteOp() HiveOperation {
-	return voteOperation{
+	return VoteOperation{
 		Voter:    "xeroc",
 		Author:   "xeroc",
 		Permlink: "piston",
@@ -22,6 +22,23 @@ func getTestCustomJsonOp() HiveOperation {
 	}
 }
 
+func getTestCommentOp() HiveOperation {
+	return CommentOperation{
+		ParentAuthor:   "",
+		ParentPermlink: "hive",
+		Author:         "xeroc",
+		Permlink:       "piston",
+		Title:          "T",
+		Body:           "B",
+		JsonMetadata:   "{}",
+	}
+}
+
+func getTestExpiration() CustomTime {
+	exp, _ := time.Parse("2006-01-02T15:04:05", "2016-08-08T12:24:17")
+	return CustomTime(exp)
+}
+
 func getTestAccountUpdateOp() HiveOperation {
 	return AccountUpdateOperation{
 		Account:      "sniperduel17",
@@ -41,7 +58,7 @@ func getTestTransferOp() HiveOperation {
 		To:     "vsc.gateway",
 		From:   "tibfox.vsc",
 		Memo:   "to=tibfox",
-		Amount: "1.000 HIVE",
+		Amount: MustParseAsset("1.000 HIVE"),
 	}
 }
 
@@ -0,0 +1,207 @@
+package hivego
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v2"
+)
+
+// The block a transaction references for TaPoS (transaction as proof of
+// stake). The transaction is only valid on a chain containing that block.
+type TaposRef int
+
+const (
+	// the head block, as used by Broadcast
+	TaposHead TaposRef = iota
+	// the last irreversible block, which keeps the transaction valid across
+	// micro-forks
+	TaposLastIrreversible
+	// the block set with ReferenceBlock
+	TaposBlock
+)
+
+const (
+	defaultTxExpiration = 30 * time.Second
+	// HIVE_MAX_TIME_UNTIL_EXPIRATION
+	maxTxExpiration = time.Hour
+)
+
+// Builds a transaction out of operations from several helpers. Build, Sign
+// and Broadcast are separate steps so the transaction can be inspected or
+// signed by several parties in between:
+//
+//	b := node.NewTransaction().AddOps(op1, op2).ReferenceLastIrreversible()
+//	tx, err := b.Build()
+//	err = b.Sign(&wif)
+//	txId, err := b.Broadcast()
+type TransactionBuilder struct {
+	node       *HiveRpcNode
+	ops        []HiveOperation
+	expiration time.Duration
+	tapos      TaposRef
+	refBlockId string
+	tx         *HiveTransaction
+}
+
+func (h *HiveRpcNode) NewTransaction() *TransactionBuilder {
+	return &TransactionBuilder{node: h, expiration: defaultTxExpiration}
+}
+
+func (b *TransactionBuilder) AddOps(ops ...HiveOperation) *TransactionBuilder {
+	b.ops = append(b.ops, ops...)
+	return b
+}
+
+// Sets how long after the head block time the transaction expires, at most
+// one hour. Defaults to 30 seconds.
+func (b *TransactionBuilder) Expiration(expiration time.Duration) *TransactionBuilder {
+	b.expiration = expiration
+	return b
+}
+
+// Reference the head block, the default
+func (b *TransactionBuilder) ReferenceHead() *TransactionBuilder {
+	b.tapos, b.refBlockId = TaposHead, ""
+	return b
+}
+
+func (b *TransactionBuilder) ReferenceLastIrreversible() *TransactionBuilder {
+	b.tapos, b.refBlockId = TaposLastIrreversible, ""
+	return b
+}
+
+// Reference the block with the given id, which must be one of the last 65536
+// blocks when the transaction is included
+func (b *TransactionBuilder) ReferenceBlock(blockId string) *TransactionBuilder {
+	b.tapos, b.refBlockId = TaposBlock, blockId
+	return b
+}
+
+// Builds the unsigned transaction, replacing a previously built one along
+// with its signatures
+func (b *TransactionBuilder) Build() (*HiveTransaction, error) {
+	if err := b.check(); err != nil {
+		return nil, err
+	}
+	props, err := b.node.GetGlobalProps()
+	if err != nil {
+		return nil, err
+	}
+
+	var refBlockId string
+	switch b.tapos {
+	case TaposHead:
+		refBlockId = props.HeadBlockId
+	case TaposLastIrreversible:
+		block, err := b.node.GetBlock(props.LastIrreversibleBlockNum)
+		if err != nil {
+			return nil, err
+		}
+		if block.BlockID == "" {
+			return nil, fmt.Errorf("last irreversible block %d not found", props.LastIrreversibleBlockNum)
+		}
+		refBlockId = block.BlockID
+	case TaposBlock:
+		refBlockId = b.refBlockId
+	}
+
+	return b.build(props.Time, refBlockId)
+}
+
+func (b *TransactionBuilder) check() error {
+	if len(b.ops) == 0 {
+		return errors.New("transaction has no operations")
+	}
+	if b.expiration <= 0 || b.expiration > maxTxExpiration {
+		return fmt.Errorf("expiration must be between 0 and %s", maxTxExpiration)
+	}
+	if b.tapos == TaposBlock {
+		if _, _, err := taposFromBlockId(b.refBlockId); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (b *TransactionBuilder) build(headTime string, refBlockId string) (*HiveTransaction, error) {
+	if err := b.check(); err != nil {
+		return nil, err
+	}
+	refBlockNum, refBlockPrefix, err := taposFromBlockId(refBlockId)
+	if err != nil {
+		return nil, err
+	}
+	head, err := time.Parse("2006-01-02T15:04:05", headTime)
+	if err != nil {
+		return nil, err
+	}
+
+	ops := make([]HiveOperation, len(b.ops))
+	copy(ops, b.ops)
+	b.tx = &HiveTransaction{
+		RefBlockNum:    refBlockNum,
+		RefBlockPrefix: refBlockPrefix,
+		Expiration:     head.Add(b.expiration).Format("2006-01-02T15:04:05"),
+		Operations:     ops,
+	}
+	return b.tx, nil
+}
+
+// Signs the built transaction with each of wifs
+func (b *TransactionBuilder) Sign(wifs ...*string) error {
+	keyPairs := make([]*KeyPair, 0, len(wifs))
+	for _, wif := range wifs {
+		keyPair, err := KeyPairFromWif(*wif)
+		if err != nil {
+			return err
+		}
+		keyPairs = append(keyPairs, keyPair)
+	}
+	return b.SignWith(keyPairs...)
+}
+
+// Signs the built transaction with each of keyPairs
+func (b *TransactionBuilder) SignWith(keyPairs ...*KeyPair) error {
+	if b.tx == nil {
+		return errors.New("transaction must be built before signing")
+	}
+	message, err := SerializeTx(*b.tx)
+	if err != nil {
+		return err
+	}
+	digest := HashTxForSig(message, b.node.ChainID)
+
+	for _, keyPair := range keyPairs {
+		sig, err := secp256k1.SignCompact(keyPair.PrivateKey, digest, true)
+		if err != nil {
+			return err
+		}
+		b.tx.AddSig(hex.EncodeToString(sig))
+	}
+	return nil
+}
+
+// Broadcasts the built and signed transaction and returns its id
+func (b *TransactionBuilder) Broadcast() (string, error) {
+	if b.tx == nil {
+		return "", errors.New("transaction must be built before broadcasting")
+	}
+	return b.node.BroadcastRaw(*b.tx)
+}
+
+// Returns the ref_block_num and ref_block_prefix referencing a block. The
+// block number is in the first 4 bytes of the id, big endian.
+func taposFromBlockId(blockId string) (uint16, uint32, error) {
+	id, err := hex.DecodeString(blockId)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid block id %q: %w", blockId, err)
+	}
+	if len(id) != 20 {
+		return 0, 0, fmt.Errorf("invalid block id %q: expected 20 bytes", blockId)
+	}
+	return uint16(binary.BigEndian.Uint32(id[:4])), binary.LittleEndian.Uint32(id[4:8]), nil
+}
@@ -0,0 +1,77 @@
+package hivego
+
+import (
+	"encoding/hex"
+	"testing"
+	"time"
+)
+
+const testRefBlockId = "00008cbd5fe26f45000000000000000000000000"
+
+func TestTaposFromBlockId(t *testing.T) {
+	num, prefix, err := taposFromBlockId(testRefBlockId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if num != 36029 || prefix != 1164960351 {
+		t.Error("Expected 36029 and 1164960351, got", num, prefix)
+	}
+
+	for _, invalid := range []string{"", "00008cbd", "zz008cbd5fe26f45000000000000000000000000"} {
+		if _, _, err := taposFromBlockId(invalid); err == nil {
+			t.Error("Expected an error for", invalid)
+		}
+	}
+}
+
+func TestTransactionBuilder(t *testing.T) {
+	b := NewHiveRpc([]string{"https://api.hive.blog"}).NewTransaction().AddOps(getTestVoteOp())
+	if err := b.Sign(); err == nil {
+		t.Error("Expected an error signing before building")
+	}
+
+	tx, err := b.build("2016-08-08T12:23:47", testRefBlockId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := getTestVoteTx()
+	if tx.RefBlockNum != expected.RefBlockNum || tx.RefBlockPrefix != expected.RefBlockPrefix || tx.Expiration != expected.Expiration {
+		t.Error("Expected", expected, "got", *tx)
+	}
+
+	wif := "5JuMt237G3m3BaT7zH4YdoycUtbw4AEPy6DLdCrKAnFGAtXyQ1W"
+	if err := b.Sign(&wif); err != nil {
+		t.Fatal(err)
+	}
+	message, _ := SerializeTx(expected)
+	sig, _ := SignDigest(HashTxForSig(message), &wif)
+	if len(tx.Signatures) != 1 || tx.Signatures[0] != hex.EncodeToString(sig) {
+		t.Error("Expected signature", hex.EncodeToString(sig), "got", tx.Signatures)
+	}
+	txId, _ := tx.GenerateTrxId()
+	if txId != "12164dcee518674c586e6a61d08623c44980e326" {
+		t.Error("Unexpected transaction id", txId)
+	}
+}
+
+func TestTransactionBuilderExpiration(t *testing.T) {
+	b := NewHiveRpc([]string{"https://api.hive.blog"}).NewTransaction().AddOps(getTestVoteOp())
+
+	tx, err := b.Expiration(time.Hour).build("2016-08-08T12:24:17", testRefBlockId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tx.Expiration != "2016-08-08T13:24:17" {
+		t.Error("Expected 2016-08-08T13:24:17, got", tx.Expiration)
+	}
+
+	if _, err := b.Expiration(time.Hour + time.Second).Build(); err == nil {
+		t.Error("Expected an error for an expiration over one hour")
+	}
+	if _, err := b.Expiration(time.Minute).ReferenceBlock("1234").Build(); err == nil {
+		t.Error("Expected an error for an invalid reference block")
+	}
+	if _, err := NewHiveRpc([]string{"https://api.hive.blog"}).NewTransaction().Build(); err == nil {
+		t.Error("Expected an error for a transaction without operations")
+	}
+}
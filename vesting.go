@@ -0,0 +1,54 @@
+package hivego
+
+import (
+	"errors"
+	"math/big"
+)
+
+// Converts an amount of HIVE Power (e.g. "100.000 HIVE") to VESTS using the
+// vesting fund and shares of the given global properties snapshot
+func ConvertHPToVests(hp Asset, props GlobalProps) (Asset, error) {
+	if err := hp.checkSymbol(props.TotalVestingFundHive.Symbol); err != nil {
+		return Asset{}, err
+	}
+	fund, shares, err := vestingRatio(props)
+	if err != nil {
+		return Asset{}, err
+	}
+
+	vests := new(big.Int).Mul(big.NewInt(hp.Amount), shares)
+	vests.Quo(vests, fund)
+	if !vests.IsInt64() {
+		return Asset{}, errors.New("vests amount out of range")
+	}
+
+	return NewAsset(vests.Int64(), "VESTS")
+}
+
+// Converts an amount of VESTS (e.g. "1000.000000 VESTS") to HIVE Power using
+// the vesting fund and shares of the given global properties snapshot
+func ConvertVestsToHP(vests Asset, props GlobalProps) (Asset, error) {
+	if err := vests.checkSymbol("VESTS"); err != nil {
+		return Asset{}, err
+	}
+	fund, shares, err := vestingRatio(props)
+	if err != nil {
+		return Asset{}, err
+	}
+
+	hp := new(big.Int).Mul(big.NewInt(vests.Amount), fund)
+	hp.Quo(hp, shares)
+	if !hp.IsInt64() {
+		return Asset{}, errors.New("hive power amount out of range")
+	}
+
+	return NewAsset(hp.Int64(), props.TotalVestingFundHive.Symbol)
+}
+
+func vestingRatio(props GlobalProps) (*big.Int, *big.Int, error) {
+	fund, shares := props.TotalVestingFundHive.Amount, props.TotalVestingShares.Amount
+	if fund <= 0 || shares <= 0 {
+		return nil, nil, errors.New("global properties have no vesting fund or shares")
+	}
+	return big.NewInt(fund), big.NewInt(shares), nil
+}
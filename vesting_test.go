@@ -0,0 +1,37 @@
+package hivego
+
+import "testing"
+
+func getTestGlobalProps() GlobalProps {
+	return GlobalProps{
+		TotalVestingFundHive: MustParseAsset("180000000.000 HIVE"),
+		TotalVestingShares:   MustParseAsset("300000000000.000000 VESTS"),
+	}
+}
+
+func TestConvertHPToVests(t *testing.T) {
+	got, err := ConvertHPToVests(MustParseAsset("100.000 HIVE"), getTestGlobalProps())
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := "166666.666666 VESTS"
+	if got.String() != expected {
+		t.Error("Expected", expected, "got", got)
+	}
+}
+
+func TestConvertVestsToHP(t *testing.T) {
+	got, err := ConvertVestsToHP(MustParseAsset("166666.666666 VESTS"), getTestGlobalProps())
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := "99.999 HIVE"
+	if got.String() != expected {
+		t.Error("Expected", expected, "got", got)
+	}
+
+	_, err = ConvertVestsToHP(MustParseAsset("1.000000 VESTS"), GlobalProps{})
+	if err == nil {
+		t.Error("Expected an error for empty global properties")
+	}
+}
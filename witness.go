@@ -0,0 +1,153 @@
+package hivego
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"sort"
+)
+
+// Public key made of zeros, used to disable a witness
+const NullPublicKey = "STM1111111111111111111111111111111114T1Anm"
+
+// Properties of witness_set_properties. Each property is stored already
+// serialized, as the chain expects a map of property name to bytes. Setters
+// can be chained and the first error is kept for Err.
+type WitnessProps struct {
+	props map[string][]byte
+	err   error
+}
+
+// Starts a set of witness properties. signingKey must be the current block
+// signing key of the witness, the chain requires it in every update.
+func NewWitnessProps(signingKey string) *WitnessProps {
+	p := &WitnessProps{props: make(map[string][]byte)}
+	return p.setKey("key", signingKey)
+}
+
+func (p *WitnessProps) set(name string, encode func(*bytes.Buffer) error) *WitnessProps {
+	if p.err != nil {
+		return p
+	}
+	if p.props == nil {
+		p.props = make(map[string][]byte)
+	}
+	var buf bytes.Buffer
+	if err := encode(&buf); err != nil {
+		p.err = err
+		return p
+	}
+	p.props[name] = buf.Bytes()
+	return p
+}
+
+func (p *WitnessProps) setKey(name string, key string) *WitnessProps {
+	return p.set(name, func(b *bytes.Buffer) error {
+		return appendPublicKey(key, b)
+	})
+}
+
+func (p *WitnessProps) AccountCreationFee(fee string) *WitnessProps {
+	return p.set("account_creation_fee", func(b *bytes.Buffer) error {
+		asset, err := ParseAsset(fee)
+		if err != nil {
+			return err
+		}
+		return appendAsset(asset, b)
+	})
+}
+
+func (p *WitnessProps) MaximumBlockSize(size uint32) *WitnessProps {
+	return p.set("maximum_block_size", func(b *bytes.Buffer) error {
+		appendUint32(size, b)
+		return nil
+	})
+}
+
+// Interest rate on HBD savings in basis points
+func (p *WitnessProps) HbdInterestRate(rate uint16) *WitnessProps {
+	return p.set("hbd_interest_rate", func(b *bytes.Buffer) error {
+		appendUint16(rate, b)
+		return nil
+	})
+}
+
+// Price feed, with the same semantics as feed_publish
+func (p *WitnessProps) HbdExchangeRate(rate Price) *WitnessProps {
+	return p.set("hbd_exchange_rate", func(b *bytes.Buffer) error {
+		return appendPrice(rate, b)
+	})
+}
+
+func (p *WitnessProps) AccountSubsidyBudget(budget int32) *WitnessProps {
+	return p.set("account_subsidy_budget", func(b *bytes.Buffer) error {
+		appendUint32(uint32(budget), b)
+		return nil
+	})
+}
+
+func (p *WitnessProps) AccountSubsidyDecay(decay uint32) *WitnessProps {
+	return p.set("account_subsidy_decay", func(b *bytes.Buffer) error {
+		appendUint32(decay, b)
+		return nil
+	})
+}
+
+func (p *WitnessProps) Url(url string) *WitnessProps {
+	return p.set("url", func(b *bytes.Buffer) error {
+		if url == "" {
+			return errors.New("witness url must not be empty")
+		}
+		appendVString(url, b)
+		return nil
+	})
+}
+
+func (p *WitnessProps) NewSigningKey(key string) *WitnessProps {
+	return p.setKey("new_signing_key", key)
+}
+
+// Returns the first error raised while setting a property
+func (p *WitnessProps) Err() error {
+	if p.err == nil && p.props["key"] == nil {
+		return errors.New("witness properties need the current signing key")
+	}
+	return p.err
+}
+
+// property names in the order of the serialized flat_map
+func (p WitnessProps) keys() []string {
+	keys := make([]string, 0, len(p.props))
+	for k := range p.props {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Marshals to the [[name, hex bytes], ...] form of the condenser API
+func (p WitnessProps) MarshalJSON() ([]byte, error) {
+	pairs := make([][2]string, 0, len(p.props))
+	for _, k := range p.keys() {
+		pairs = append(pairs, [2]string{k, hex.EncodeToString(p.props[k])})
+	}
+	return json.Marshal(pairs)
+}
+
+func (p *WitnessProps) UnmarshalJSON(b []byte) error {
+	var pairs [][2]string
+	if err := json.Unmarshal(b, &pairs); err != nil {
+		return err
+	}
+	props := make(map[string][]byte, len(pairs))
+	for _, pair := range pairs {
+		value, err := hex.DecodeString(pair[1])
+		if err != nil {
+			return err
+		}
+		props[pair[0]] = value
+	}
+	*p = WitnessProps{props: props}
+	return nil
+}